@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/phyrwork/bogglr/pkg/api"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// authenticate attaches the session of the bearer token of each request,
+// if it has one, to the context of the request.
+func authenticate(resolver *api.Resolver, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, err := resolver.Authorize(req.Context(), req.Header.Get("Authorization"))
+		if err != nil {
+			writeError(w, api.ErrorPresenter(req.Context(), err))
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// authenticateWebsocket attaches the session of the bearer token in the
+// connection_init payload of a websocket, if it has one, to the context
+// of the connection, since browsers cannot set headers on websockets.
+func authenticateWebsocket(resolver *api.Resolver) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, error) {
+		authorized, err := resolver.Authorize(ctx, payload.Authorization())
+		if err != nil {
+			return nil, errors.New(api.ErrorPresenter(ctx, err).Message)
+		}
+		return authorized, nil
+	}
+}
+
+// writeError responds to a request that fails before it is resolved with
+// an error as a GraphQL response would present it.
+func writeError(w http.ResponseWriter, err *gqlerror.Error) {
+	status := http.StatusInternalServerError
+	if err.Extensions["code"] == string(api.CodeUnauthenticated) {
+		status = http.StatusUnauthorized
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": gqlerror.List{err}})
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
+	"crypto/rand"
 	"github.com/phyrwork/bogglr/pkg/api"
 	"github.com/phyrwork/bogglr/pkg/api/generated"
+	"github.com/phyrwork/bogglr/pkg/boggle"
 	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/phyrwork/bogglr/pkg/pubsub"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 )
 
@@ -30,12 +38,60 @@ func main() {
 		log.Fatalf("database migrate error: %v", err)
 	}
 
-	resolver := api.Resolver{DB: db}
+	var dict boggle.Lexicon
+	if name := os.Getenv("DICT"); strings.HasSuffix(name, ".dawg") {
+		var g *boggle.DAWG
+		if g, err = boggle.OpenDAWG(name); err != nil {
+			log.Fatalf("dictionary load error: %v", err)
+		}
+		defer g.Close()
+		dict = g
+	} else if name != "" {
+		d := &boggle.Dict{}
+		if err = d.ReadFile(name, boggle.DefaultNormalizer); err != nil {
+			log.Fatalf("dictionary load error: %v", err)
+		}
+		dict = boggle.NewDAWG(d)
+	} else {
+		log.Print("no dictionary configured: set DICT to the path of a word list or .dawg file")
+	}
+
+	var scorer boggle.Scorer
+	if name := os.Getenv("SCORING"); name != "" {
+		if scorer, err = boggle.ParseTable(name); err != nil {
+			log.Fatalf("scoring table error: %v", err)
+		}
+	}
+
+	var sessionKey []byte
+	if key := os.Getenv("SESSION_KEY"); key != "" {
+		sessionKey = []byte(key)
+	} else {
+		sessionKey = make([]byte, 32)
+		if _, err = rand.Read(sessionKey); err != nil {
+			log.Fatalf("session key error: %v", err)
+		}
+		log.Print("no session key configured: set SESSION_KEY for sessions to outlast the server")
+	}
+
+	resolver := api.Resolver{DB: db, Dict: dict, Scorer: scorer, Broker: pubsub.NewMemory(), SessionKey: sessionKey}
 	config := generated.Config{Resolvers: &resolver}
-	srv := handler.NewDefaultServer(generated.NewExecutableSchema(config))
+	srv := handler.New(generated.NewExecutableSchema(config))
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: 10 * time.Second,
+		InitFunc:              authenticateWebsocket(&resolver),
+	})
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.SetQueryCache(lru.New(1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{Cache: lru.New(100)})
+	srv.SetErrorPresenter(api.ErrorPresenter)
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", authenticate(&resolver, resolver.LoaderMiddleware(srv)))
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
@@ -0,0 +1,56 @@
+// Command dawg compiles a word list into a DAWG file that the API
+// server can load without rebuilding it.
+//
+// Usage:
+//
+//	dawg [-o words.dawg] words.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default: input with .dawg extension)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-o file] wordlist\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+	if *out == "" {
+		base := strings.TrimSuffix(name, ".gz")
+		if i := strings.LastIndexByte(base, '.'); i > strings.LastIndexByte(base, os.PathSeparator) {
+			base = base[:i]
+		}
+		*out = base + ".dawg"
+	}
+
+	d := &boggle.Dict{}
+	if err := d.ReadFile(name, boggle.DefaultNormalizer); err != nil {
+		log.Fatalf("dictionary load error: %v", err)
+	}
+	g := boggle.NewDAWG(d)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("create error: %v", err)
+	}
+	if _, err := g.WriteTo(f); err != nil {
+		log.Fatalf("write error: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("write error: %v", err)
+	}
+	log.Printf("wrote %d words in %d nodes to %s", g.Len(), g.Nodes(), *out)
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+)
+
+// SessionDuration is how long a player stays logged in.
+const SessionDuration = 30 * 24 * time.Hour
+
+// MinPasswordLength is the least number of characters in a password.
+const MinPasswordLength = 8
+
+var (
+	errNotLoggedIn       = errorf(CodeUnauthenticated, "not logged in")
+	errInvalidToken      = errorf(CodeUnauthenticated, "invalid token")
+	errInvalidSession    = errorf(CodeUnauthenticated, "invalid session")
+	errUnsupportedScheme = errorf(CodeUnauthenticated, "unsupported authorization scheme")
+)
+
+func checkPassword(password string) error {
+	if n := len([]rune(password)); n < MinPasswordLength {
+		return errorf(CodeInvalidArgument, "password is too short: must be at least %d characters", MinPasswordLength).withField("password")
+	}
+	return nil
+}
+
+// signToken returns the token of a session, which is its ID signed with
+// the session key so that tokens cannot be forged.
+func (r *Resolver) signToken(id int) (string, error) {
+	if len(r.SessionKey) == 0 {
+		return "", errors.New("sessions are not configured")
+	}
+	payload := base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+	mac := hmac.New(sha256.New, r.SessionKey)
+	mac.Write([]byte(payload))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// parseToken returns the ID of the session of a token.
+func (r *Resolver) parseToken(token string) (int, error) {
+	if len(r.SessionKey) == 0 {
+		return 0, errors.New("sessions are not configured")
+	}
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, errInvalidToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return 0, errInvalidToken
+	}
+	mac := hmac.New(sha256.New, r.SessionKey)
+	mac.Write([]byte(payload))
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return 0, errInvalidToken
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return 0, errInvalidToken
+	}
+	id, err := strconv.Atoi(string(b))
+	if err != nil {
+		return 0, errInvalidToken
+	}
+	return id, nil
+}
+
+type sessionKey struct{}
+
+// Authenticate returns a context that carries the session of a token,
+// which the resolvers take to be made by the player of the session.
+func (r *Resolver) Authenticate(ctx context.Context, token string) (context.Context, error) {
+	id, err := r.parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	var session database.Session
+	err = r.DB.WithContext(ctx).First(&session, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorf(CodeUnauthenticated, "session has ended")
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if session.IsExpired(now()) {
+		return nil, errorf(CodeUnauthenticated, "session has expired")
+	}
+	return context.WithValue(ctx, sessionKey{}, &session), nil
+}
+
+// Authorize returns a context that carries the session of the bearer
+// token in an Authorization header, if it has one. A token that does not
+// identify a valid session is an invalid session, for a reason that is
+// logged rather than returned.
+func (r *Resolver) Authorize(ctx context.Context, header string) (context.Context, error) {
+	if header == "" {
+		return ctx, nil
+	}
+	token := strings.TrimPrefix(header, "Bearer ")
+	if token == header {
+		return nil, errUnsupportedScheme
+	}
+	authorized, err := r.Authenticate(ctx, token)
+	var e *Error
+	if errors.As(err, &e) && e.Code == CodeUnauthenticated {
+		log.Printf("invalid session: %v", err)
+		return nil, errInvalidSession
+	}
+	return authorized, err
+}
+
+// sessionOf returns the session that a request is made in, or nil if the
+// request is not authenticated.
+func sessionOf(ctx context.Context) *database.Session {
+	session, _ := ctx.Value(sessionKey{}).(*database.Session)
+	return session
+}
+
+// caller returns the ID of the player that makes a request.
+func caller(ctx context.Context) (int, error) {
+	session := sessionOf(ctx)
+	if session == nil {
+		return 0, errNotLoggedIn
+	}
+	return session.PlayerID, nil
+}
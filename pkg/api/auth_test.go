@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	r := &Resolver{SessionKey: []byte("secret")}
+	token, err := r.signToken(42)
+	assert.NoError(t, err)
+	id, err := r.parseToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	other := &Resolver{SessionKey: []byte("other")}
+	forged, _ := other.signToken(42)
+	for _, s := range []string{"", "42", token[:len(token)-1], forged} {
+		_, err := r.parseToken(s)
+		assert.Errorf(t, err, "parsed invalid token '%s'", s)
+	}
+
+	_, err = (&Resolver{}).signToken(42)
+	assert.Error(t, err, "signed token without key")
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+	got, err := (&Resolver{}).Authorize(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, ctx, got)
+
+	tests := map[string]struct {
+		key     []byte
+		header  string
+		code    ErrorCode
+		message string
+	}{
+		"Scheme":       {[]byte("secret"), "Basic YWxpY2U6aHVudGVyMjI=", CodeUnauthenticated, "unsupported authorization scheme"},
+		"Token":        {[]byte("secret"), "Bearer 42", CodeUnauthenticated, "invalid session"},
+		"Unconfigured": {nil, "Bearer 42", CodeInternal, "internal error"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &Resolver{SessionKey: tt.key}
+			_, err := r.Authorize(ctx, tt.header)
+			e := classify(err)
+			assert.Equal(t, tt.code, e.Code)
+			assert.Equal(t, tt.message, e.Error())
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	assert.NoError(t, checkPassword("hunter22"))
+	assert.Error(t, checkPassword("hunter2"))
+}
+
+func TestSession(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		r := &Resolver{DB: tx, SessionKey: []byte("secret")}
+		password := "hunter22"
+		player, err := r.Mutation().CreatePlayer(ctx, "alice", &password)
+		if err != nil {
+			t.Fatalf("create player error: %v", err)
+		}
+
+		_, err = r.Mutation().Login(ctx, "alice", "hunter2")
+		assert.Error(t, err)
+		session, err := r.Mutation().Login(ctx, "alice", password)
+		if err != nil {
+			t.Fatalf("login error: %v", err)
+		}
+		assert.Equal(t, player, session.Player)
+
+		me, err := r.Query().Me(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, me)
+		_, err = r.Mutation().CreateWord(ctx, GlobalID(NodeGame, 1), []model.Point{{0, 0}}, nil)
+		assert.ErrorIs(t, err, errNotLoggedIn)
+
+		authCtx, err := r.Authenticate(ctx, session.Token)
+		if err != nil {
+			t.Fatalf("authenticate error: %v", err)
+		}
+		me, err = r.Query().Me(authCtx)
+		assert.NoError(t, err)
+		assert.Equal(t, player, me)
+
+		ok, err := r.Mutation().Logout(authCtx)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		_, err = r.Authenticate(ctx, session.Token)
+		assert.Error(t, err, "authenticated after logout")
+	})
+}
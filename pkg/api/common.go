@@ -20,3 +20,11 @@ func MapPointersOf[T any, U any](a []T, f func(T) U) []*U {
 	b := MapOf(a, f)
 	return PointersOf(b)
 }
+
+func KeysOf[K comparable, V any](m map[K]V) []K {
+	a := make([]K, 0, len(m))
+	for k := range m {
+		a = append(a, k)
+	}
+	return a
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+)
+
+// gameDict returns the dictionary that a game is played against,
+// which is the default dictionary if the game has none, or nil if there
+// is no default dictionary either.
+func (r *Resolver) gameDict(ctx context.Context, game *model.Game) (boggle.Lexicon, error) {
+	if game.DictionaryID == nil {
+		return r.Dict, nil
+	}
+	id := *game.DictionaryID
+	if dict, ok := r.dicts.Load(id); ok {
+		return dict.(*boggle.DAWG), nil
+	}
+	record := database.Dictionary{ID: id}
+	err := r.DB.WithContext(ctx).Preload("Words").First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorf(CodeNotFound, "dictionary '%d' not found", id)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	dict, _ := r.dicts.LoadOrStore(id, boggle.NewDAWG(record.DumpDict()))
+	return dict.(*boggle.DAWG), nil
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// readDict reads a dictionary in the given format, which may be gzip-compressed.
+func readDict(r io.Reader, format model.DictionaryFormat) (*boggle.Dict, error) {
+	read := (*boggle.Dict).ReadList
+	switch format {
+	case model.DictionaryFormatList:
+	case model.DictionaryFormatHunspell:
+		read = (*boggle.Dict).ReadHunspell
+	default:
+		return nil, errorf(CodeInvalidArgument, "unknown dictionary format '%s'", format).withField("format")
+	}
+	dict := &boggle.Dict{}
+	br := bufio.NewReader(r)
+	var err error
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		err = dict.ReadGzip(br, boggle.DefaultNormalizer, read)
+	} else {
+		err = read(dict, br, boggle.DefaultNormalizer)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// dictionaryID checks that a dictionary exists and returns its ID.
+func (r *Resolver) dictionaryID(ctx context.Context, id *string) (*int, error) {
+	if id == nil {
+		return nil, nil
+	}
+	value, err := DecodeID(NodeDictionary, *id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Query().Dictionary(ctx, *id); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
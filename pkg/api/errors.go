@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgconn"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
+)
+
+// ErrorCode classifies an error for clients, who receive it as
+// extensions.code.
+type ErrorCode string
+
+const (
+	CodeNotFound           ErrorCode = "NOT_FOUND"
+	CodeInvalidArgument    ErrorCode = "INVALID_ARGUMENT"
+	CodeBoardTooLarge      ErrorCode = "BOARD_TOO_LARGE"
+	CodeConflict           ErrorCode = "CONFLICT"
+	CodeUnauthenticated    ErrorCode = "UNAUTHENTICATED"
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeFailedPrecondition ErrorCode = "FAILED_PRECONDITION"
+	CodeInternal           ErrorCode = "INTERNAL"
+)
+
+// Error is an error with a code, which is presented to clients along
+// with the argument at fault and any other details.
+type Error struct {
+	Code ErrorCode
+	// Field is the name of the argument at fault, if any.
+	Field string
+	// Details are further extensions that describe the error.
+	Details map[string]interface{}
+	err     error
+}
+
+// errorf returns an error with a code, formatted as by fmt.Errorf.
+func errorf(code ErrorCode, format string, a ...interface{}) *Error {
+	return &Error{Code: code, err: fmt.Errorf(format, a...)}
+}
+
+// withField sets the argument at fault and returns the error.
+func (e *Error) withField(field string) *Error {
+	e.Field = field
+	return e
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
+
+// PathError converts a path validation error into an error that
+// identifies the failed rule and the index of the offending point.
+func PathError(err error) error {
+	var pathErr *boggle.PathError
+	if !errors.As(err, &pathErr) {
+		return err
+	}
+	details := map[string]interface{}{
+		"rule": string(pathErr.Rule),
+	}
+	if pathErr.Index >= 0 {
+		details["index"] = pathErr.Index
+	}
+	e := errorf(CodeInvalidArgument, "invalid path: %w", pathErr).withField("path")
+	e.Details = details
+	return e
+}
+
+// PostgreSQL error codes.
+const (
+	pgUniqueViolation           = "23505"
+	pgForeignKeyViolation       = "23503"
+	pgCheckViolation            = "23514"
+	pgNotNullViolation          = "23502"
+	pgStringDataRightTruncation = "22001"
+	pgNumericValueOutOfRange    = "22003"
+	pgInvalidTextRepresentation = "22P02"
+)
+
+// boardConstraint is the constraint on the number of rows of a board.
+const boardConstraint = "chk_games_board"
+
+// constraintErrors are the errors presented for violations of database
+// constraints by name.
+var constraintErrors = map[string]struct {
+	code    ErrorCode
+	field   string
+	message string
+}{
+	boardConstraint:         {CodeBoardTooLarge, "board", "board is too tall"},
+	"idx_player_login":      {CodeConflict, "name", "player name is taken"},
+	"idx_word":              {CodeConflict, "path", "word already exists"},
+	"idx_game_host":         {CodeConflict, "", "game already has a host"},
+	"idx_games_invite_code": {CodeConflict, "", "invite code is taken"},
+}
+
+// isPgError returns whether err was caused by a PostgreSQL error with a
+// code and, if given, violating a constraint.
+func isPgError(err error, code string, constraint string) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == code && (constraint == "" || pgErr.ConstraintName == constraint)
+}
+
+// classify returns the error that err is presented as, which describes
+// database errors without revealing their details.
+func classify(err error) *Error {
+	var (
+		e         *Error
+		statusErr *database.StatusError
+		pgErr     *pgconn.PgError
+		gqlErr    *gqlerror.Error
+	)
+	switch {
+	case errors.As(err, &e):
+		return &Error{Code: e.Code, Field: e.Field, Details: e.Details, err: err}
+	case errors.As(err, &statusErr):
+		return &Error{Code: CodeConflict, err: err}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return &Error{Code: CodeNotFound, err: err}
+	case errors.As(err, &pgErr):
+		if c, ok := constraintErrors[pgErr.ConstraintName]; ok {
+			return &Error{Code: c.code, Field: c.field, err: errors.New(c.message)}
+		}
+		switch pgErr.Code {
+		case pgUniqueViolation:
+			return &Error{Code: CodeConflict, err: errors.New("already exists")}
+		case pgForeignKeyViolation:
+			return &Error{Code: CodeNotFound, err: errors.New("referenced record not found")}
+		case pgCheckViolation, pgNotNullViolation, pgStringDataRightTruncation, pgNumericValueOutOfRange, pgInvalidTextRepresentation:
+			return &Error{Code: CodeInvalidArgument, err: errors.New("invalid value")}
+		}
+	case errors.As(err, &gqlErr):
+		// gqlgen reports invalid arguments as GraphQL errors.
+		return &Error{Code: CodeInvalidArgument, err: errors.New(gqlErr.Message)}
+	}
+	return &Error{Code: CodeInternal, err: errors.New("internal error")}
+}
+
+// ErrorPresenter presents errors to clients with their code, as
+// extensions.code, and the argument at fault, as extensions.field. The
+// details of internal errors are logged rather than presented.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	e := classify(err)
+	if e.Code == CodeInternal {
+		log.Printf("internal error at %v: %v", graphql.GetPath(ctx), err)
+	}
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	extensions := make(map[string]interface{}, len(gqlErr.Extensions)+len(e.Details)+2)
+	for k, v := range gqlErr.Extensions {
+		extensions[k] = v
+	}
+	for k, v := range e.Details {
+		extensions[k] = v
+	}
+	extensions["code"] = string(e.Code)
+	if e.Field != "" {
+		extensions["field"] = e.Field
+	}
+	return &gqlerror.Error{
+		Message:    e.Error(),
+		Path:       gqlErr.Path,
+		Locations:  gqlErr.Locations,
+		Extensions: extensions,
+		Rule:       gqlErr.Rule,
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/jackc/pgconn"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"gorm.io/gorm"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    ErrorCode
+		field   string
+		message string
+	}{
+		{
+			name:    "Error",
+			err:     fmt.Errorf("cannot start game '1': %w", errorf(CodeForbidden, "caller is not the host")),
+			code:    CodeForbidden,
+			message: "cannot start game '1': caller is not the host",
+		},
+		{
+			name:    "Field",
+			err:     errorf(CodeInvalidArgument, "invalid duration 0: must be positive").withField("duration"),
+			code:    CodeInvalidArgument,
+			field:   "duration",
+			message: "invalid duration 0: must be positive",
+		},
+		{
+			name:    "Status",
+			err:     fmt.Errorf("cannot start game '1': %w", &database.StatusError{Status: database.GameRunning}),
+			code:    CodeConflict,
+			message: "cannot start game '1': game is running",
+		},
+		{
+			name:    "RecordNotFound",
+			err:     fmt.Errorf("database error: %w", gorm.ErrRecordNotFound),
+			code:    CodeNotFound,
+			message: "database error: record not found",
+		},
+		{
+			name:    "Constraint",
+			err:     fmt.Errorf("database error: %w", &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "idx_player_login", Message: "secret"}),
+			code:    CodeConflict,
+			field:   "name",
+			message: "player name is taken",
+		},
+		{
+			name:    "Board",
+			err:     fmt.Errorf("database error: %w", &pgconn.PgError{Code: pgCheckViolation, ConstraintName: boardConstraint}),
+			code:    CodeBoardTooLarge,
+			field:   "board",
+			message: "board is too tall",
+		},
+		{
+			name:    "UniqueViolation",
+			err:     fmt.Errorf("database error: %w", &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "idx_secret"}),
+			code:    CodeConflict,
+			message: "already exists",
+		},
+		{
+			name:    "ForeignKeyViolation",
+			err:     fmt.Errorf("database error: %w", &pgconn.PgError{Code: pgForeignKeyViolation}),
+			code:    CodeNotFound,
+			message: "referenced record not found",
+		},
+		{
+			name:    "DatabaseError",
+			err:     fmt.Errorf("database error: %w", &pgconn.PgError{Code: "57014", Message: "secret"}),
+			code:    CodeInternal,
+			message: "internal error",
+		},
+		{
+			name:    "Argument",
+			err:     &gqlerror.Error{Message: "invalid point x"},
+			code:    CodeInvalidArgument,
+			message: "invalid point x",
+		},
+		{
+			name:    "Internal",
+			err:     errors.New("secret"),
+			code:    CodeInternal,
+			message: "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := classify(tt.err)
+			assert.Equal(t, tt.code, e.Code)
+			assert.Equal(t, tt.field, e.Field)
+			assert.Equal(t, tt.message, e.Error())
+		})
+	}
+}
+
+func TestIsPgError(t *testing.T) {
+	err := fmt.Errorf("database error: %w", &pgconn.PgError{Code: pgCheckViolation, ConstraintName: boardConstraint})
+	assert.True(t, isPgError(err, pgCheckViolation, ""))
+	assert.True(t, isPgError(err, pgCheckViolation, boardConstraint))
+	assert.False(t, isPgError(err, pgCheckViolation, "chk_other"))
+	assert.False(t, isPgError(err, pgUniqueViolation, ""))
+	assert.False(t, isPgError(errors.New("violates check"), pgCheckViolation, ""))
+}
+
+func TestErrorPresenter(t *testing.T) {
+	ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, nil)
+
+	gqlErr := ErrorPresenter(ctx, errors.New("secret"))
+	assert.Equal(t, "internal error", gqlErr.Message)
+	assert.Equal(t, map[string]interface{}{"code": "INTERNAL"}, gqlErr.Extensions)
+
+	gqlErr = ErrorPresenter(ctx, PathError(&boggle.PathError{Rule: boggle.RuleAdjacent, Index: 2}))
+	assert.Equal(t, map[string]interface{}{
+		"code":  "INVALID_ARGUMENT",
+		"field": "path",
+		"rule":  string(boggle.RuleAdjacent),
+		"index": 2,
+	}, gqlErr.Extensions)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+)
+
+func gameTopic(gameID string) string {
+	return "game:" + gameID
+}
+
+// publish publishes an event to the subscribers of its game. Events are
+// a side effect of the change that caused them, so errors are logged
+// rather than returned.
+func (r *Resolver) publish(ctx context.Context, event model.GameEvent) {
+	if r.Broker == nil {
+		return
+	}
+	msg, err := json.Marshal(event)
+	if err == nil {
+		err = r.Broker.Publish(ctx, gameTopic(event.GameID), msg)
+	}
+	if err != nil {
+		log.Printf("error publishing %s event for game '%s': %v", event.Type, event.GameID, err)
+	}
+}
+
+// gameEvents sends the events of a game to a channel until the context
+// is done. As well as the events published to the game, it sends a
+// ROUND_FINISHED event when a timed round comes to the end of its
+// duration, which happens without any change being made to the game.
+func (r *Resolver) gameEvents(ctx context.Context, game *model.Game) (<-chan *model.GameEvent, error) {
+	if r.Broker == nil {
+		return nil, errors.New("subscriptions not available")
+	}
+	msgs, err := r.Broker.Subscribe(ctx, gameTopic(game.ID))
+	if err != nil {
+		return nil, fmt.Errorf("subscribe error: %w", err)
+	}
+	events := make(chan *model.GameEvent, 1)
+	go func() {
+		defer close(events)
+		var timer *time.Timer
+		schedule := func(endsAt *time.Time) {
+			if timer != nil {
+				timer.Stop()
+				timer = nil
+			}
+			if endsAt != nil {
+				timer = time.NewTimer(endsAt.Sub(now()))
+			}
+		}
+		defer schedule(nil)
+		if game.Status == model.GameStatusRunning {
+			schedule(game.EndsAt)
+		}
+		for {
+			var (
+				event   model.GameEvent
+				expired <-chan time.Time
+			)
+			if timer != nil {
+				expired = timer.C
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				if err := json.Unmarshal(msg, &event); err != nil {
+					log.Printf("error decoding event for game '%s': %v", game.ID, err)
+					continue
+				}
+				switch event.Type {
+				case model.GameEventTypeRoundStarted:
+					schedule(event.EndsAt)
+				case model.GameEventTypeRoundFinished:
+					schedule(nil)
+				}
+			case <-expired:
+				timer = nil
+				event = model.GameEvent{Type: model.GameEventTypeRoundFinished, GameID: game.ID}
+			}
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
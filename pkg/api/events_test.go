@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/pubsub"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func receiveEvent(t *testing.T, events <-chan *model.GameEvent) *model.GameEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+func TestResolver_gameEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := Resolver{Broker: pubsub.NewMemory()}
+	events, err := r.gameEvents(ctx, &model.Game{ID: "1", Status: model.GameStatusCreated})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wordID := "2"
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeWordSubmitted, GameID: "3"})
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeWordSubmitted, GameID: "1", WordID: &wordID})
+	assert.Equal(t, &model.GameEvent{Type: model.GameEventTypeWordSubmitted, GameID: "1", WordID: &wordID}, receiveEvent(t, events))
+
+	// A timed round finishes by itself.
+	endsAt := now().Add(50 * time.Millisecond)
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundStarted, GameID: "1", EndsAt: &endsAt})
+	assert.Equal(t, model.GameEventTypeRoundStarted, receiveEvent(t, events).Type)
+	assert.Equal(t, &model.GameEvent{Type: model.GameEventTypeRoundFinished, GameID: "1"}, receiveEvent(t, events))
+
+	// A round finished early does not finish again.
+	endsAt = now().Add(50 * time.Millisecond)
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundStarted, GameID: "1", EndsAt: &endsAt})
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundFinished, GameID: "1"})
+	assert.Equal(t, model.GameEventTypeRoundStarted, receiveEvent(t, events).Type)
+	assert.Equal(t, model.GameEventTypeRoundFinished, receiveEvent(t, events).Type)
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Events end with the subscription.
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultLoaderWait is how long a Loader waits for more keys before it
+// fetches a batch.
+const DefaultLoaderWait = time.Millisecond
+
+// Loader batches the loads of values by key that are made at about the
+// same time, such as by the resolvers of the items of a list, into one
+// fetch. Values are not cached beyond the batch they were fetched in.
+type Loader[K comparable, V any] struct {
+	// Fetch returns the values of keys, where a key that has no value
+	// is missing from the map.
+	Fetch func(keys []K) (map[K]V, error)
+	// Wait is how long to wait for more keys, or DefaultLoaderWait if 0.
+	Wait time.Duration
+	// MaxBatch is the greatest number of keys in a batch, or unlimited if 0.
+	MaxBatch int
+
+	mu    sync.Mutex
+	batch *loaderBatch[K, V]
+}
+
+type loaderBatch[K comparable, V any] struct {
+	keys   []K
+	seen   map[K]bool
+	once   sync.Once
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+// Load returns the value of key, or the zero value if it has none.
+func (l *Loader[K, V]) Load(key K) (V, error) {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[K, V]{seen: make(map[K]bool), done: make(chan struct{})}
+		l.batch = b
+		wait := l.Wait
+		if wait == 0 {
+			wait = DefaultLoaderWait
+		}
+		time.AfterFunc(wait, func() { l.dispatch(b) })
+	}
+	if !b.seen[key] {
+		b.seen[key] = true
+		b.keys = append(b.keys, key)
+	}
+	if l.MaxBatch > 0 && len(b.keys) >= l.MaxBatch {
+		l.batch = nil
+		l.mu.Unlock()
+		go l.dispatch(b)
+	} else {
+		l.mu.Unlock()
+	}
+	<-b.done
+	return b.values[key], b.err
+}
+
+// dispatch fetches a batch, unless it has already been fetched.
+func (l *Loader[K, V]) dispatch(b *loaderBatch[K, V]) {
+	b.once.Do(func() {
+		l.mu.Lock()
+		if l.batch == b {
+			l.batch = nil
+		}
+		l.mu.Unlock()
+		b.values, b.err = l.Fetch(b.keys)
+		close(b.done)
+	})
+}
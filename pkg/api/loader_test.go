@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// loadAll loads keys concurrently, as the resolvers of a list do.
+func loadAll[K comparable, V any](l *Loader[K, V], keys []K) ([]V, []error) {
+	values, errs := make([]V, len(keys)), make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func(i int, key K) {
+			defer wg.Done()
+			values[i], errs[i] = l.Load(key)
+		}(i, key)
+	}
+	wg.Wait()
+	return values, errs
+}
+
+func TestLoader(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int
+	)
+	l := &Loader[int, string]{
+		Fetch: func(keys []int) (map[int]string, error) {
+			mu.Lock()
+			batches = append(batches, keys)
+			mu.Unlock()
+			values := make(map[int]string)
+			for _, key := range keys {
+				if key != 0 {
+					values[key] = string(rune('a' + key))
+				}
+			}
+			return values, nil
+		},
+		Wait: 10 * time.Millisecond,
+	}
+	values, errs := loadAll(l, []int{1, 2, 3, 2, 0})
+	assert.Equal(t, []string{"b", "c", "d", "c", ""}, values)
+	assert.Equal(t, make([]error, 5), errs)
+	if assert.Len(t, batches, 1) {
+		sort.Ints(batches[0])
+		assert.Equal(t, []int{0, 1, 2, 3}, batches[0])
+	}
+
+	// Loads after a batch is fetched are in a new batch.
+	value, err := l.Load(4)
+	assert.NoError(t, err)
+	assert.Equal(t, "e", value)
+	assert.Len(t, batches, 2)
+}
+
+func TestLoader_MaxBatch(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		sizes []int
+	)
+	l := &Loader[int, int]{
+		Fetch: func(keys []int) (map[int]int, error) {
+			mu.Lock()
+			sizes = append(sizes, len(keys))
+			mu.Unlock()
+			values := make(map[int]int)
+			for _, key := range keys {
+				values[key] = key * key
+			}
+			return values, nil
+		},
+		Wait:     10 * time.Millisecond,
+		MaxBatch: 2,
+	}
+	values, _ := loadAll(l, []int{1, 2, 3, 4, 5})
+	assert.Equal(t, []int{1, 4, 9, 16, 25}, values)
+	total := 0
+	for _, size := range sizes {
+		assert.LessOrEqual(t, size, 2)
+		total += size
+	}
+	assert.Equal(t, 5, total)
+}
+
+func TestLoader_Error(t *testing.T) {
+	fetchErr := errors.New("fetch error")
+	l := &Loader[int, int]{Fetch: func(keys []int) (map[int]int, error) {
+		return nil, fetchErr
+	}}
+	_, errs := loadAll(l, []int{1, 2})
+	assert.Equal(t, []error{fetchErr, fetchErr}, errs)
+}
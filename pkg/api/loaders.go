@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/phyrwork/bogglr/pkg/database"
+)
+
+// Loaders batches the loads of the relations of records made while
+// resolving a request.
+type Loaders struct {
+	WordGame    *Loader[int, *database.Game]
+	WordPlayers *Loader[int, []database.Player]
+	PlayerWords *Loader[int, []database.Word]
+}
+
+// NewLoaders returns the loaders of a request. Their fetches are made
+// one at a time, so that a request may be served in a transaction.
+func NewLoaders(ctx context.Context, db *database.DB) *Loaders {
+	var mu sync.Mutex
+	db = db.WithContext(ctx)
+	return &Loaders{
+		WordGame: &Loader[int, *database.Game]{Fetch: serial(&mu, func(ids []int) (map[int]*database.Game, error) {
+			var records []database.Word
+			if err := db.Select("id", "game_id").Preload("Game").Find(&records, ids).Error; err != nil {
+				return nil, err
+			}
+			games := make(map[int]*database.Game, len(records))
+			for _, record := range records {
+				games[record.ID] = record.Game
+			}
+			return games, nil
+		})},
+		WordPlayers: &Loader[int, []database.Player]{Fetch: serial(&mu, func(ids []int) (map[int][]database.Player, error) {
+			var records []database.Word
+			if err := db.Select("id").Preload("Players").Find(&records, ids).Error; err != nil {
+				return nil, err
+			}
+			players := make(map[int][]database.Player, len(records))
+			for _, record := range records {
+				players[record.ID] = record.Players
+			}
+			return players, nil
+		})},
+		PlayerWords: &Loader[int, []database.Word]{Fetch: serial(&mu, func(ids []int) (map[int][]database.Word, error) {
+			var records []database.Player
+			if err := db.Select("id").Preload("Words").Find(&records, ids).Error; err != nil {
+				return nil, err
+			}
+			words := make(map[int][]database.Word, len(records))
+			for _, record := range records {
+				words[record.ID] = record.Words
+			}
+			return words, nil
+		})},
+	}
+}
+
+func serial[K comparable, V any](mu *sync.Mutex, fetch func([]K) (map[K]V, error)) func([]K) (map[K]V, error) {
+	return func(keys []K) (map[K]V, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return fetch(keys)
+	}
+}
+
+type loadersKey struct{}
+
+// WithLoaders returns a context that carries the loaders of a request.
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// LoaderMiddleware adds new loaders to the context of each request.
+func (r *Resolver) LoaderMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		next.ServeHTTP(w, req.WithContext(WithLoaders(ctx, NewLoaders(ctx, r.DB))))
+	})
+}
+
+// loaders returns the loaders of a request, or loaders that batch
+// nothing beyond a single load if the request has none.
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	if loaders, ok := ctx.Value(loadersKey{}).(*Loaders); ok {
+		return loaders
+	}
+	return NewLoaders(ctx, r.DB)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/phyrwork/bogglr/pkg/api/generated"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoaderMiddleware(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		players := []database.Player{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		game := database.Game{Board: database.Board{"abcd", "efgh", "ijkl", "mnop"}}
+		if err := tx.WithContext(ctx).Create(&game).Error; err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		for y := 0; y < 3; y++ {
+			for x := 0; x < 4; x++ {
+				word := database.Word{
+					GameID:  game.ID,
+					Path:    database.Path{{x, y}, {x, y + 1}},
+					Players: players[:1+(x+y)%len(players)],
+				}
+				if err := tx.WithContext(ctx).Create(&word).Error; err != nil {
+					t.Fatalf("create word error: %v", err)
+				}
+			}
+		}
+
+		var queries int64
+		count := func(*database.DB) {
+			atomic.AddInt64(&queries, 1)
+		}
+		_ = tx.Callback().Query().After("gorm:query").Register("test:count_queries", count)
+		_ = tx.Callback().Row().After("gorm:row").Register("test:count_rows", count)
+		defer func() {
+			_ = tx.Callback().Query().Remove("test:count_queries")
+			_ = tx.Callback().Row().Remove("test:count_rows")
+		}()
+
+		resolver := &Resolver{DB: tx}
+		srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+		c := client.New(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// Wait long enough for every load of a level of the query to
+			// join its batch, however slowly the resolvers are scheduled.
+			loaders := NewLoaders(req.Context(), tx)
+			loaders.WordGame.Wait = 100 * time.Millisecond
+			loaders.WordPlayers.Wait = 100 * time.Millisecond
+			loaders.PlayerWords.Wait = 100 * time.Millisecond
+			srv.ServeHTTP(w, req.WithContext(WithLoaders(req.Context(), loaders)))
+		}))
+		words := func(n int) (int, int64) {
+			var resp struct {
+				Words struct {
+					Edges []struct {
+						Node struct {
+							Game    struct{ ID string }
+							Players []struct {
+								Words []struct{ ID string }
+							}
+						}
+					}
+				}
+			}
+			atomic.StoreInt64(&queries, 0)
+			c.MustPost(`query($gameId: ID, $first: Int) {
+				words(gameId: $gameId, first: $first) {
+					edges { node { game { id } players { words { id } } } }
+				}
+			}`, &resp, client.Var("gameId", GlobalID(NodeGame, game.ID)), client.Var("first", n))
+			for _, edge := range resp.Words.Edges {
+				assert.Equal(t, GlobalID(NodeGame, game.ID), edge.Node.Game.ID)
+				assert.NotEmpty(t, edge.Node.Players)
+			}
+			return len(resp.Words.Edges), atomic.LoadInt64(&queries)
+		}
+
+		// A page of words takes 4 queries: to count the words, find them
+		// and check for words before and after them. Loading the games of
+		// the words then takes 2 queries, their players 3 and the words of
+		// those players 3, however many words there are.
+		const want = 4 + 2 + 3 + 3
+		n, few := words(2)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, int64(want), few)
+		n, many := words(12)
+		assert.Equal(t, 12, n)
+		assert.Equal(t, int64(want), many, "queries grow with the number of words")
+	})
+}
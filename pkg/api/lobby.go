@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+)
+
+// membership returns the membership of a player in a game, or nil if
+// they are not a member.
+func membership(tx *gorm.DB, gameID, playerID int) (*database.GamePlayer, error) {
+	var member database.GamePlayer
+	err := tx.Where("game_id = ? AND player_id = ?", gameID, playerID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// checkHost checks that the caller may manage a game, which anyone may
+// unless the game has a lobby, in which only its host may.
+func checkHost(ctx context.Context, tx *gorm.DB, game *database.Game) error {
+	if game.InviteCode == nil {
+		return nil
+	}
+	playerID, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	member, err := membership(tx, game.ID, playerID)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if member == nil || !member.Role.IsHost() {
+		return errorf(CodeForbidden, "caller is not the host")
+	}
+	return nil
+}
+
+// leaveGame removes a player from a game, returning whether they were a
+// member. If the host leaves then the player who joined first hosts, or
+// else the spectator who joined first, who still spectates. A lobby that
+// everyone has left is closed, as nobody could manage it: its game is
+// finished if it is running and deleted if it has not started.
+func leaveGame(tx *gorm.DB, game *database.Game, playerID int) (bool, error) {
+	member, err := membership(tx, game.ID, playerID)
+	if member == nil || err != nil {
+		return false, err
+	}
+	if err := tx.Delete(member).Error; err != nil {
+		return false, err
+	}
+	if !member.Role.IsHost() {
+		return true, nil
+	}
+	for _, role := range []database.GameRole{database.RolePlayer, database.RoleSpectator} {
+		var next database.GamePlayer
+		err = tx.Where("game_id = ? AND role = ?", game.ID, role).
+			Order("joined_at, player_id").
+			First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		return true, tx.Model(&next).Update("role", role.Hosting()).Error
+	}
+	switch game.Status(now()) {
+	case database.GameCreated:
+		return true, tx.Delete(game).Error
+	case database.GameRunning:
+		if err := game.Finish(now()); err != nil {
+			return false, err
+		}
+		return true, tx.Save(game).Error
+	}
+	return true, nil
+}
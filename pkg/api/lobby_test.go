@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLobby(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		dict := &boggle.Dict{}
+		dict.Insert("cat")
+		r := &Resolver{DB: tx, Dict: dict}
+
+		players := []database.Player{{Name: "alice"}, {Name: "bob"}, {Name: "carol"}}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		alice, bob, carol := withCaller(ctx, players[0].ID), withCaller(ctx, players[1].ID), withCaller(ctx, players[2].ID)
+		roles := func(game *model.Game) map[string]model.GameRole {
+			members, err := r.Game().Players(ctx, game)
+			assert.NoError(t, err)
+			m := make(map[string]model.GameRole)
+			for _, member := range members {
+				m[member.Player.Name] = member.Role
+			}
+			return m
+		}
+
+		game, err := r.Mutation().CreateGame(ctx, []string{"cat", "xyz"}, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		_, err = r.Mutation().CreateLobby(ctx, game.ID)
+		assert.ErrorIs(t, err, errNotLoggedIn)
+		if game, err = r.Mutation().CreateLobby(alice, game.ID); err != nil {
+			t.Fatalf("create lobby error: %v", err)
+		}
+		_, err = r.Mutation().CreateLobby(alice, game.ID)
+		assert.Error(t, err, "created lobby twice")
+
+		// Only members can see the invite code.
+		code, err := r.Game().InviteCode(alice, game)
+		if assert.NoError(t, err) && assert.NotNil(t, code) {
+			assert.Len(t, *code, database.InviteCodeLength)
+		}
+		hidden, err := r.Game().InviteCode(bob, game)
+		assert.NoError(t, err)
+		assert.Nil(t, hidden)
+
+		_, err = r.Mutation().JoinGame(bob, "nope", nil)
+		assert.Error(t, err)
+		host := model.GameRoleHost
+		_, err = r.Mutation().JoinGame(bob, *code, &host)
+		assert.Error(t, err, "joined as host")
+		_, err = r.Mutation().JoinGame(bob, *code, nil)
+		assert.NoError(t, err)
+		spectator := model.GameRoleSpectator
+		_, err = r.Mutation().JoinGame(carol, *code, &spectator)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]model.GameRole{
+			"alice": model.GameRoleHost,
+			"bob":   model.GameRolePlayer,
+			"carol": model.GameRoleSpectator,
+		}, roles(game))
+
+		// Only the host can start the game, and only players can submit words.
+		_, err = r.Mutation().StartGame(bob, game.ID, nil)
+		assert.Error(t, err, "started game as player")
+		if _, err = r.Mutation().StartGame(alice, game.ID, nil); err != nil {
+			t.Fatalf("start game error: %v", err)
+		}
+		path := []model.Point{{0, 0}, {1, 0}, {2, 0}}
+		_, err = r.Mutation().SubmitWord(carol, game.ID, path, nil)
+		assert.Error(t, err, "submitted word as spectator")
+		_, err = r.Mutation().SubmitWord(bob, game.ID, path, nil)
+		assert.NoError(t, err)
+
+		// The next player hosts when the host leaves.
+		left, err := r.Mutation().LeaveGame(alice, game.ID)
+		assert.NoError(t, err)
+		assert.True(t, left)
+		left, err = r.Mutation().LeaveGame(alice, game.ID)
+		assert.NoError(t, err)
+		assert.False(t, left)
+		assert.Equal(t, map[string]model.GameRole{
+			"bob":   model.GameRoleHost,
+			"carol": model.GameRoleSpectator,
+		}, roles(game))
+
+		// A spectator hosts when no player can, but still cannot submit words.
+		if _, err = r.Mutation().LeaveGame(bob, game.ID); err != nil {
+			t.Fatalf("leave game error: %v", err)
+		}
+		assert.Equal(t, map[string]model.GameRole{
+			"carol": model.GameRoleSpectatingHost,
+		}, roles(game))
+		_, err = r.Mutation().SubmitWord(carol, game.ID, path, nil)
+		assert.Error(t, err, "submitted word as spectating host")
+
+		// The host keeps hosting whether they play or spectate.
+		player := model.GameRolePlayer
+		if _, err = r.Mutation().JoinGame(carol, *code, &player); err != nil {
+			t.Fatalf("join game error: %v", err)
+		}
+		assert.Equal(t, map[string]model.GameRole{
+			"carol": model.GameRoleHost,
+		}, roles(game))
+		if _, err = r.Mutation().JoinGame(carol, *code, &spectator); err != nil {
+			t.Fatalf("join game error: %v", err)
+		}
+		assert.Equal(t, map[string]model.GameRole{
+			"carol": model.GameRoleSpectatingHost,
+		}, roles(game))
+
+		// A running game that everyone has left is finished.
+		_, err = r.Mutation().FinishGame(alice, game.ID)
+		assert.Error(t, err, "finished game as stranger")
+		if _, err = r.Mutation().LeaveGame(carol, game.ID); err != nil {
+			t.Fatalf("leave game error: %v", err)
+		}
+		assert.Empty(t, roles(game))
+		if game, err = r.Query().Game(ctx, game.ID); assert.NoError(t, err) {
+			assert.Equal(t, model.GameStatusFinished, game.Status)
+		}
+
+		other, err := r.Mutation().CreateGame(ctx, []string{"cat", "xyz"}, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		if other, err = r.Mutation().CreateLobby(alice, other.ID); err != nil {
+			t.Fatalf("create lobby error: %v", err)
+		}
+		code, _ = r.Game().InviteCode(alice, other)
+		if _, err = r.Mutation().JoinGame(carol, *code, &spectator); err != nil {
+			t.Fatalf("join game error: %v", err)
+		}
+		if _, err = r.Mutation().LeaveGame(alice, other.ID); err != nil {
+			t.Fatalf("leave game error: %v", err)
+		}
+		_, err = r.Mutation().StartGame(bob, other.ID, nil)
+		assert.Error(t, err, "started game as stranger after host left")
+		_, err = r.Mutation().StartGame(carol, other.ID, nil)
+		assert.NoError(t, err, "spectator cannot start game after host left")
+
+		// A game that everyone has left before it started is deleted.
+		unstarted, err := r.Mutation().CreateGame(ctx, []string{"cat", "xyz"}, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		if _, err = r.Mutation().CreateLobby(alice, unstarted.ID); err != nil {
+			t.Fatalf("create lobby error: %v", err)
+		}
+		left, err = r.Mutation().LeaveGame(alice, unstarted.ID)
+		assert.NoError(t, err)
+		assert.True(t, left)
+		_, err = r.Query().Game(ctx, unstarted.ID)
+		assert.Error(t, err, "game remains after everyone left")
+	})
+}
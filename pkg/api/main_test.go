@@ -0,0 +1,47 @@
+package api
+
+import (
+	"log"
+	"os"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/pkg/errors"
+)
+
+var db *database.DB
+
+func WithRollback(db *database.DB, f func(tx *database.DB)) {
+	OK := errors.New("ok")
+	err := db.Transaction(func(tx *database.DB) error {
+		f(tx)
+		return OK
+	})
+	if err != OK {
+		log.Fatal(errors.Wrap(err, "transaction error"))
+	}
+}
+
+func TestMain(m *testing.M) {
+	var err error
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if db, err = database.Open(dsn); err != nil {
+		log.Print(errors.Wrap(err, "error opening database"))
+		db = nil
+	}
+	if db == nil && os.Getenv("CI") != "" {
+		log.Fatal("database not available in CI build")
+	}
+	// See database.TestMain for why this is not DB.Begin().
+	if db != nil {
+		WithRollback(db, func(tx *database.DB) {
+			if err = database.Migrate(tx); err != nil {
+				log.Fatal(errors.Wrap(err, "error migrating database"))
+			}
+			db = tx
+			m.Run()
+		})
+	} else {
+		m.Run()
+	}
+}
@@ -6,13 +6,38 @@ import (
 	"github.com/phyrwork/bogglr/pkg/database/grammar"
 	"io"
 	"strconv"
+	"time"
 )
 
 type Board = database.Board
 
 type Game struct {
-	ID    string `json:"id"`
-	Board Board  `json:"board"`
+	ID       string  `json:"id"`
+	Board    Board   `json:"board"`
+	DiceSet  *string `json:"diceSet"`
+	Seed     *int64  `json:"seed"`
+	Topology string  `json:"topology"`
+
+	Status    GameStatus `json:"status"`
+	Duration  *int       `json:"duration"`
+	StartedAt *time.Time `json:"startedAt"`
+	EndsAt    *time.Time `json:"endsAt"`
+
+	DictionaryID *int    `json:"-"`
+	InviteCode   *string `json:"-"`
+}
+
+func (Game) IsNode() {}
+
+// GameEvent is an event in a game, which refers to the records it
+// concerns by ID so that it can be published as a message.
+type GameEvent struct {
+	Type     GameEventType `json:"type"`
+	GameID   string        `json:"gameId"`
+	WordID   *string       `json:"wordId,omitempty"`
+	PlayerID *string       `json:"playerId,omitempty"`
+	// EndsAt is when the round is due to end, for ROUND_STARTED.
+	EndsAt *time.Time `json:"endsAt,omitempty"`
 }
 
 type Point database.Point
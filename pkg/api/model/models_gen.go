@@ -2,9 +2,48 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// An object with an ID that is unique across all types, by which it can be refetched with node.
+type Node interface {
+	IsNode()
+}
+
+type DictionariesConnection struct {
+	Edges    []*DictionariesEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
+type DictionariesEdge struct {
+	Cursor string      `json:"cursor"`
+	Node   *Dictionary `json:"node"`
+}
+
+type Dictionary struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Language  string `json:"language"`
+	Version   string `json:"version"`
+	WordCount int    `json:"wordCount"`
+	Hash      string `json:"hash"`
+}
+
+func (Dictionary) IsNode() {}
+
+type GamePlayer struct {
+	Player   *Player   `json:"player"`
+	Role     GameRole  `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
 type GamesConnection struct {
-	Edges    []*Game   `json:"edges"`
-	PageInfo *PageInfo `json:"pageInfo"`
+	Edges    []*GamesEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
 }
 
 type GamesEdge struct {
@@ -13,40 +52,263 @@ type GamesEdge struct {
 }
 
 type PageInfo struct {
-	StartCursor string `json:"startCursor"`
-	EndCursor   string `json:"endCursor"`
-	HasNextPage *bool  `json:"hasNextPage"`
+	// Cursor of the first item in the page, or null if the page is empty.
+	StartCursor *string `json:"startCursor"`
+	// Cursor of the last item in the page, or null if the page is empty.
+	EndCursor       *string `json:"endCursor"`
+	HasNextPage     *bool   `json:"hasNextPage"`
+	HasPreviousPage *bool   `json:"hasPreviousPage"`
+	// Number of items in all of the pages.
+	TotalCount int `json:"totalCount"`
 }
 
 type Player struct {
 	ID    string  `json:"id"`
 	Name  string  `json:"name"`
 	Words []*Word `json:"words"`
+	Score int     `json:"score"`
+}
+
+func (Player) IsNode() {}
+
+type PlayerScore struct {
+	Player *Player `json:"player"`
+	Score  int     `json:"score"`
 }
 
 type PlayersConnection struct {
-	Edges    []*Player `json:"edges"`
-	PageInfo *PageInfo `json:"pageInfo"`
+	Edges    []*PlayersEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
 }
 
 type PlayersEdge struct {
-	Cursor string `json:"cursor"`
-	Node   *Game  `json:"node"`
+	Cursor string  `json:"cursor"`
+	Node   *Player `json:"node"`
+}
+
+// A login of a player. Requests are made by the player when the token is given as 'Authorization: Bearer <token>'.
+type Session struct {
+	Token     string    `json:"token"`
+	Player    *Player   `json:"player"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type Solution struct {
+	Word  string    `json:"word"`
+	Paths [][]Point `json:"paths"`
+	Score int       `json:"score"`
 }
 
 type Word struct {
 	ID      string    `json:"id"`
 	Game    *Game     `json:"game"`
 	Path    []Point   `json:"path"`
+	Text    string    `json:"text"`
 	Players []*Player `json:"players"`
 }
 
+func (Word) IsNode() {}
+
+type WordSubmission struct {
+	Word *Word `json:"word"`
+	// Whether the word had not been found in the game before.
+	New bool `json:"new"`
+	// Whether the player had already submitted the word.
+	Duplicate bool `json:"duplicate"`
+}
+
 type WordsConnection struct {
-	Edges    []*Word   `json:"edges"`
-	PageInfo *PageInfo `json:"pageInfo"`
+	Edges    []*WordsEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
 }
 
 type WordsEdge struct {
 	Cursor string `json:"cursor"`
 	Node   *Word  `json:"node"`
 }
+
+type DictionaryFormat string
+
+const (
+	// Newline-separated list of words.
+	DictionaryFormatList DictionaryFormat = "LIST"
+	// Hunspell .dic file, of which only the stems are used.
+	DictionaryFormatHunspell DictionaryFormat = "HUNSPELL"
+)
+
+var AllDictionaryFormat = []DictionaryFormat{
+	DictionaryFormatList,
+	DictionaryFormatHunspell,
+}
+
+func (e DictionaryFormat) IsValid() bool {
+	switch e {
+	case DictionaryFormatList, DictionaryFormatHunspell:
+		return true
+	}
+	return false
+}
+
+func (e DictionaryFormat) String() string {
+	return string(e)
+}
+
+func (e *DictionaryFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DictionaryFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DictionaryFormat", str)
+	}
+	return nil
+}
+
+func (e DictionaryFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GameEventType string
+
+const (
+	// A word was created in the game.
+	GameEventTypeWordSubmitted GameEventType = "WORD_SUBMITTED"
+	// A player joined the game.
+	GameEventTypePlayerJoined GameEventType = "PLAYER_JOINED"
+	// The round started.
+	GameEventTypeRoundStarted GameEventType = "ROUND_STARTED"
+	// The round finished, either early or at the end of its duration, and the scores are final.
+	GameEventTypeRoundFinished GameEventType = "ROUND_FINISHED"
+)
+
+var AllGameEventType = []GameEventType{
+	GameEventTypeWordSubmitted,
+	GameEventTypePlayerJoined,
+	GameEventTypeRoundStarted,
+	GameEventTypeRoundFinished,
+}
+
+func (e GameEventType) IsValid() bool {
+	switch e {
+	case GameEventTypeWordSubmitted, GameEventTypePlayerJoined, GameEventTypeRoundStarted, GameEventTypeRoundFinished:
+		return true
+	}
+	return false
+}
+
+func (e GameEventType) String() string {
+	return string(e)
+}
+
+func (e *GameEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GameEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GameEventType", str)
+	}
+	return nil
+}
+
+func (e GameEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GameRole string
+
+const (
+	// Manages the game, and plays.
+	GameRoleHost   GameRole = "HOST"
+	GameRolePlayer GameRole = "PLAYER"
+	// Watches the game without submitting words.
+	GameRoleSpectator GameRole = "SPECTATOR"
+	// Manages the game, and watches it without submitting words.
+	GameRoleSpectatingHost GameRole = "SPECTATING_HOST"
+)
+
+var AllGameRole = []GameRole{
+	GameRoleHost,
+	GameRolePlayer,
+	GameRoleSpectator,
+	GameRoleSpectatingHost,
+}
+
+func (e GameRole) IsValid() bool {
+	switch e {
+	case GameRoleHost, GameRolePlayer, GameRoleSpectator, GameRoleSpectatingHost:
+		return true
+	}
+	return false
+}
+
+func (e GameRole) String() string {
+	return string(e)
+}
+
+func (e *GameRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GameRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GameRole", str)
+	}
+	return nil
+}
+
+func (e GameRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type GameStatus string
+
+const (
+	// Waiting to start.
+	GameStatusCreated GameStatus = "CREATED"
+	// Accepting words.
+	GameStatusRunning GameStatus = "RUNNING"
+	// No longer accepting words.
+	GameStatusFinished GameStatus = "FINISHED"
+)
+
+var AllGameStatus = []GameStatus{
+	GameStatusCreated,
+	GameStatusRunning,
+	GameStatusFinished,
+}
+
+func (e GameStatus) IsValid() bool {
+	switch e {
+	case GameStatusCreated, GameStatusRunning, GameStatusFinished:
+		return true
+	}
+	return false
+}
+
+func (e GameStatus) String() string {
+	return string(e)
+}
+
+func (e *GameStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = GameStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid GameStatus", str)
+	}
+	return nil
+}
+
+func (e GameStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+)
+
+func playerOf(record database.Player) model.Player {
+	return model.Player{
+		ID:   GlobalID(NodePlayer, record.ID),
+		Name: record.Name,
+	}
+}
+
+func gameOf(record database.Game) model.Game {
+	topology := "square"
+	if record.Topology != nil {
+		topology = *record.Topology
+	}
+	return model.Game{
+		ID:           GlobalID(NodeGame, record.ID),
+		Board:        record.Board,
+		DiceSet:      record.DiceSet,
+		Seed:         record.Seed,
+		Topology:     topology,
+		Status:       model.GameStatus(record.Status(now())),
+		Duration:     record.Duration,
+		StartedAt:    record.StartedAt,
+		EndsAt:       record.EndsAt,
+		DictionaryID: record.DictionaryID,
+		InviteCode:   record.InviteCode,
+	}
+}
+
+func dictionaryOf(record database.Dictionary) model.Dictionary {
+	return model.Dictionary{
+		ID:        GlobalID(NodeDictionary, record.ID),
+		Name:      record.Name,
+		Language:  record.Language,
+		Version:   record.Version,
+		WordCount: record.WordCount,
+		Hash:      record.Hash,
+	}
+}
+
+func wordOf(record database.Word) model.Word {
+	return model.Word{
+		ID: GlobalID(NodeWord, record.ID),
+		Path: MapOf(record.Path, func(record database.Point) model.Point {
+			return model.Point(record)
+		}),
+		Text: record.Text,
+	}
+}
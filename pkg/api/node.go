@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+)
+
+// Type names of the objects that implement Node, as they appear in
+// global IDs.
+const (
+	NodeGame       = "Game"
+	NodePlayer     = "Player"
+	NodeWord       = "Word"
+	NodeDictionary = "Dictionary"
+)
+
+// GlobalID returns the ID of an object that is unique across all types,
+// which is encoded so that clients treat it as opaque.
+func GlobalID(typename string, id int) string {
+	return base64.URLEncoding.EncodeToString([]byte(typename + ":" + strconv.Itoa(id)))
+}
+
+// ParseGlobalID returns the type name and ID of the object identified
+// by a global ID.
+func ParseGlobalID(s string) (string, int, error) {
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid id '%s': %w", s, err)
+	}
+	typename, value, ok := strings.Cut(string(b), ":")
+	if !ok || typename == "" {
+		return "", 0, fmt.Errorf("invalid id '%s': missing type", s)
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid id '%s': %w", s, err)
+	}
+	return typename, id, nil
+}
+
+// DecodeID returns the ID of an object of the given type from its global
+// ID. Plain numeric IDs, as issued before global IDs, are rejected as
+// they do not say what type of object they identify.
+func DecodeID(typename string, s string) (int, error) {
+	if _, err := strconv.Atoi(s); err == nil {
+		return 0, errorf(CodeInvalidArgument, "invalid %s id '%s': plain ids are no longer supported, use global ids", strings.ToLower(typename), s)
+	}
+	t, id, err := ParseGlobalID(s)
+	if err != nil {
+		return 0, errorf(CodeInvalidArgument, "invalid %s id '%s': %w", strings.ToLower(typename), s, err)
+	}
+	if t != typename {
+		return 0, errorf(CodeInvalidArgument, "invalid %s id '%s': is %s id", strings.ToLower(typename), s, strings.ToLower(t))
+	}
+	return id, nil
+}
+
+// node returns the object identified by a global ID, or nil if there is
+// no such object. Only an ID that is not a global ID is an error.
+func (r *Resolver) node(ctx context.Context, id string) (model.Node, error) {
+	typename, _, err := ParseGlobalID(id)
+	if err != nil {
+		return nil, errorf(CodeInvalidArgument, "%w", err).withField("id")
+	}
+	var obj model.Node
+	switch typename {
+	case NodeGame:
+		obj, err = asNode(r.Query().Game(ctx, id))
+	case NodePlayer:
+		obj, err = asNode(r.Query().Player(ctx, id))
+	case NodeWord:
+		obj, err = asNode(r.word(ctx, id))
+	case NodeDictionary:
+		obj, err = asNode(r.Query().Dictionary(ctx, id))
+	default:
+		return nil, nil
+	}
+	var e *Error
+	if errors.As(err, &e) && e.Code == CodeNotFound {
+		return nil, nil
+	}
+	return obj, err
+}
+
+// asNode returns obj as a Node, so that a nil object is a nil Node.
+func asNode[T model.Node](obj T, err error) (model.Node, error) {
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (r *Resolver) word(ctx context.Context, id string) (*model.Word, error) {
+	wordID, err := DecodeID(NodeWord, id)
+	if err != nil {
+		return nil, err
+	}
+	var record database.Word
+	err = r.DB.WithContext(ctx).First(&record, wordID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorf(CodeNotFound, "word '%s' not found", id)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	obj := wordOf(record)
+	return &obj, nil
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalID(t *testing.T) {
+	s := GlobalID(NodeGame, 42)
+	assert.NotEqual(t, GlobalID(NodePlayer, 42), s, "global ids of different types collide")
+	typename, id, err := ParseGlobalID(s)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeGame, typename)
+	assert.Equal(t, 42, id)
+
+	for _, s := range []string{"!!", GlobalID(NodeGame, 1)[1:], "R2FtZQ==", "R2FtZTp4"} { // "Game", "Game:x"
+		_, _, err := ParseGlobalID(s)
+		assert.Errorf(t, err, "parsed invalid global id '%s'", s)
+	}
+}
+
+func TestDecodeID(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want int
+		err  bool
+	}{
+		{"Global", GlobalID(NodeWord, 7), 7, false},
+		{"Plain", "7", 0, true},
+		{"OtherType", GlobalID(NodePlayer, 7), 0, true},
+		{"Invalid", "x", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := DecodeID(NodeWord, tt.s)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, id)
+		})
+	}
+}
+
+func TestNode(t *testing.T) {
+	ctx := context.Background()
+	r := &Resolver{}
+
+	_, err := r.Query().Node(ctx, "!!")
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, CodeInvalidArgument, e.Code)
+	}
+
+	obj, err := r.Query().Node(ctx, GlobalID("Unknown", 1))
+	assert.NoError(t, err)
+	assert.Nil(t, obj)
+}
+
+func TestNodes(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		r := &Resolver{DB: tx}
+		player := database.Player{Name: "alice"}
+		if err := tx.WithContext(ctx).Create(&player).Error; err != nil {
+			t.Fatalf("create player error: %v", err)
+		}
+		found := GlobalID(NodePlayer, player.ID)
+		missing := GlobalID(NodePlayer, player.ID+1)
+
+		obj, err := r.Query().Node(ctx, missing)
+		assert.NoError(t, err)
+		assert.Nil(t, obj)
+
+		nodes, err := r.Query().Nodes(ctx, []string{found, missing, GlobalID(NodeWord, 0)})
+		assert.NoError(t, err)
+		assert.Equal(t, []model.Node{&model.Player{ID: found, Name: "alice"}, nil, nil}, nodes)
+	})
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"gorm.io/gorm"
+)
+
+// DefaultPageSize is the number of items in a page if neither first nor
+// last is given.
+const DefaultPageSize = 20
+
+// MaxPageSize is the largest number of items that a page may have.
+const MaxPageSize = 100
+
+// cursor is the position of an item in a list: the values of the keys
+// that the list is sorted by and then the ID of the item. It is encoded
+// so that clients treat it as opaque.
+type cursor struct {
+	Keys []interface{} `json:"keys,omitempty"`
+	ID   int           `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, errorf(CodeInvalidArgument, "invalid cursor '%s': %w", s, err)
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, errorf(CodeInvalidArgument, "invalid cursor '%s': %w", s, err)
+	}
+	return c, nil
+}
+
+// page is the arguments of a connection that select a page of it.
+type page struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// edge is a record in a page and its cursor.
+type edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
+// paginate selects a page of the records matched by filters, ordered by
+// the columns keys and then by ID, as described by the Relay cursor
+// connections specification: the records between the cursors after and
+// before, if given, and then the first or last of those.
+func paginate[T any](ctx context.Context, db *gorm.DB, filters []func(*gorm.DB) *gorm.DB, p page, keys ...string) ([]edge[T], *model.PageInfo, error) {
+	if p.First != nil && p.Last != nil {
+		return nil, nil, errorf(CodeInvalidArgument, "cannot page by both first and last").withField("last")
+	}
+	size, backward, field := DefaultPageSize, false, "first"
+	switch {
+	case p.First != nil:
+		size = *p.First
+	case p.Last != nil:
+		size, backward, field = *p.Last, true, "last"
+	}
+	if size < 0 {
+		return nil, nil, errorf(CodeInvalidArgument, "invalid page size %d: must not be negative", size).withField(field)
+	}
+	if size > MaxPageSize {
+		return nil, nil, errorf(CodeInvalidArgument, "invalid page size %d: must not be more than %d", size, MaxPageSize).withField(field)
+	}
+	var after, before *cursor
+	for _, c := range []struct {
+		s   *string
+		pos **cursor
+	}{{p.After, &after}, {p.Before, &before}} {
+		if c.s != nil {
+			pos, err := decodeCursor(*c.s)
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(pos.Keys) != len(keys) {
+				return nil, nil, errorf(CodeInvalidArgument, "invalid cursor '%s': is not of this list", *c.s)
+			}
+			*c.pos = &pos
+		}
+	}
+
+	// Positions are compared as rows of the sort keys and ID, which
+	// PostgreSQL compares column by column.
+	columns := "(" + strings.Join(append(keys[:len(keys):len(keys)], "id"), ", ") + ")"
+	placeholders := "(" + strings.Repeat("?, ", len(keys)) + "?)"
+	compare := func(qry *gorm.DB, op string, c cursor) *gorm.DB {
+		return qry.Where(columns+" "+op+" "+placeholders, append(c.Keys[:len(c.Keys):len(c.Keys)], c.ID)...)
+	}
+	query := func() *gorm.DB {
+		return db.WithContext(ctx).Model(new(T)).Scopes(filters...)
+	}
+	exists := func(op string, c cursor) (*bool, error) {
+		var ids []int
+		if err := compare(query(), op, c).Limit(1).Pluck("id", &ids).Error; err != nil {
+			return nil, err
+		}
+		ok := len(ids) > 0
+		return &ok, nil
+	}
+
+	var (
+		info  model.PageInfo
+		total int64
+	)
+	if err := query().Count(&total).Error; err != nil {
+		return nil, nil, fmt.Errorf("database error: %w", err)
+	}
+	info.TotalCount = int(total)
+
+	qry := query()
+	if after != nil {
+		qry = compare(qry, ">", *after)
+	}
+	if before != nil {
+		qry = compare(qry, "<", *before)
+	}
+	direction := "asc"
+	if backward {
+		direction = "desc"
+	}
+	for _, key := range keys {
+		qry = qry.Order(key + " " + direction)
+	}
+	qry = qry.Order("id " + direction)
+	var records []T
+	// A limit of zero is no limit to gorm, so an empty page is not
+	// selected at all.
+	if size > 0 {
+		if err := qry.Limit(size).Find(&records).Error; err != nil {
+			return nil, nil, fmt.Errorf("database error: %w", err)
+		}
+	}
+	if backward {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+	}
+
+	edges := make([]edge[T], len(records))
+	var err error
+	if len(records) > 0 {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(new(T)); err != nil {
+			return nil, nil, fmt.Errorf("database error: %w", err)
+		}
+		positions := make([]cursor, len(records))
+		for i := range records {
+			value := reflect.ValueOf(&records[i]).Elem()
+			id, _ := stmt.Schema.PrioritizedPrimaryField.ValueOf(ctx, value)
+			positions[i].ID = id.(int)
+			for _, key := range keys {
+				v, _ := stmt.Schema.LookUpField(key).ValueOf(ctx, value)
+				positions[i].Keys = append(positions[i].Keys, v)
+			}
+			edges[i] = edge[T]{Cursor: encodeCursor(positions[i]), Node: records[i]}
+		}
+
+		start, end := edges[0].Cursor, edges[len(edges)-1].Cursor
+		info.StartCursor, info.EndCursor = &start, &end
+		if info.HasPreviousPage, err = exists("<", positions[0]); err != nil {
+			return nil, nil, fmt.Errorf("database error: %w", err)
+		}
+		if info.HasNextPage, err = exists(">", positions[len(positions)-1]); err != nil {
+			return nil, nil, fmt.Errorf("database error: %w", err)
+		}
+	} else {
+		// There are no records to look either side of, so look either
+		// side of the cursors instead.
+		no := false
+		info.HasPreviousPage, info.HasNextPage = &no, &no
+		if after != nil {
+			if info.HasPreviousPage, err = exists("<=", *after); err != nil {
+				return nil, nil, fmt.Errorf("database error: %w", err)
+			}
+		}
+		if before != nil {
+			if info.HasNextPage, err = exists(">=", *before); err != nil {
+				return nil, nil, fmt.Errorf("database error: %w", err)
+			}
+		}
+		// An empty page leaves out any records between the cursors too.
+		if size == 0 {
+			var ids []int
+			if err := qry.Limit(1).Pluck("id", &ids).Error; err != nil {
+				return nil, nil, fmt.Errorf("database error: %w", err)
+			}
+			if len(ids) > 0 {
+				yes := true
+				if backward {
+					info.HasPreviousPage = &yes
+				} else {
+					info.HasNextPage = &yes
+				}
+			}
+		}
+	}
+	return edges, &info, nil
+}
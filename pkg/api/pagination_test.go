@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	s := encodeCursor(cursor{Keys: []interface{}{"alice"}, ID: 42})
+	assert.NotContains(t, s, "42", "cursor is not opaque")
+	c, err := decodeCursor(s)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor{Keys: []interface{}{"alice"}, ID: 42}, c)
+
+	for _, s := range []string{"42", "!!", encodeCursor(cursor{ID: 1})[1:]} {
+		_, err := decodeCursor(s)
+		assert.Errorf(t, err, "decoded invalid cursor '%s'", s)
+	}
+}
+
+// nodes returns the records of a page.
+func nodes[T any](edges []edge[T]) []T {
+	return MapOf(edges, func(e edge[T]) T {
+		return e.Node
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		players := make([]database.Player, 5)
+		for i := range players {
+			players[i].Name = "player" + strconv.Itoa(i)
+		}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		two := 2
+
+		// Only count the players created here.
+		filters := []func(*database.DB) *database.DB{func(qry *database.DB) *database.DB {
+			return qry.Where("id >= ?", players[0].ID)
+		}}
+
+		// Forwards.
+		edges, info, err := paginate[database.Player](ctx, tx, filters, page{First: &two})
+		assert.NoError(t, err)
+		assert.Equal(t, players[:2], nodes(edges))
+		assert.Equal(t, 5, info.TotalCount)
+		assert.Equal(t, encodeCursor(cursor{ID: players[0].ID}), *info.StartCursor)
+		assert.Equal(t, encodeCursor(cursor{ID: players[1].ID}), *info.EndCursor)
+		assert.False(t, *info.HasPreviousPage)
+		assert.True(t, *info.HasNextPage)
+
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{First: &two, After: info.EndCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, players[2:4], nodes(edges))
+		assert.True(t, *info.HasPreviousPage)
+		assert.True(t, *info.HasNextPage)
+
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{First: &two, After: info.EndCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, players[4:], nodes(edges))
+		assert.True(t, *info.HasPreviousPage)
+		assert.False(t, *info.HasNextPage)
+
+		// Past the end.
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{First: &two, After: info.EndCursor})
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+		assert.Nil(t, info.StartCursor)
+		assert.True(t, *info.HasPreviousPage)
+		assert.False(t, *info.HasNextPage)
+		assert.Equal(t, 5, info.TotalCount)
+
+		// Backwards.
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{Last: &two})
+		assert.NoError(t, err)
+		assert.Equal(t, players[3:], nodes(edges))
+		assert.True(t, *info.HasPreviousPage)
+		assert.False(t, *info.HasNextPage)
+
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{Last: &two, Before: info.StartCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, players[1:3], nodes(edges))
+		assert.True(t, *info.HasPreviousPage)
+		assert.True(t, *info.HasNextPage)
+
+		// Between cursors.
+		after, before := encodeCursor(cursor{ID: players[0].ID}), encodeCursor(cursor{ID: players[4].ID})
+		edges, _, err = paginate[database.Player](ctx, tx, filters, page{After: &after, Before: &before})
+		assert.NoError(t, err)
+		assert.Equal(t, players[1:4], nodes(edges))
+
+		// Empty pages.
+		zero := 0
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{First: &zero})
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+		assert.Equal(t, 5, info.TotalCount)
+		assert.False(t, *info.HasPreviousPage)
+		assert.True(t, *info.HasNextPage)
+
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{Last: &zero, Before: &before})
+		assert.NoError(t, err)
+		assert.Empty(t, edges)
+		assert.True(t, *info.HasPreviousPage)
+		assert.True(t, *info.HasNextPage)
+	})
+}
+
+func TestPaginate_Invalid(t *testing.T) {
+	one, negative, large, cursor := 1, -1, MaxPageSize+1, "x"
+	for name, p := range map[string]page{
+		"FirstAndLast": {First: &one, Last: &one},
+		"Negative":     {First: &negative},
+		"TooLarge":     {Last: &large},
+		"After":        {After: &cursor},
+		"Before":       {Before: &cursor},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := paginate[database.Player](context.Background(), nil, nil, p)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPaginate_Keys(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		players := []database.Player{{Name: "carol"}, {Name: "alice"}, {Name: "bob"}, {Name: "alice"}}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		sorted := []database.Player{players[1], players[3], players[2], players[0]}
+		filters := []func(*database.DB) *database.DB{func(qry *database.DB) *database.DB {
+			return qry.Where("id >= ?", players[0].ID)
+		}}
+		two := 2
+
+		edges, info, err := paginate[database.Player](ctx, tx, filters, page{First: &two}, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, sorted[:2], nodes(edges))
+		assert.True(t, *info.HasNextPage)
+
+		edges, info, err = paginate[database.Player](ctx, tx, filters, page{First: &two, After: info.EndCursor}, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, sorted[2:], nodes(edges))
+		assert.True(t, *info.HasPreviousPage)
+		assert.False(t, *info.HasNextPage)
+
+		edges, _, err = paginate[database.Player](ctx, tx, filters, page{Last: &two, Before: info.StartCursor}, "name")
+		assert.NoError(t, err)
+		assert.Equal(t, sorted[:2], nodes(edges))
+
+		// A cursor of a list that is sorted differently is invalid.
+		_, _, err = paginate[database.Player](ctx, tx, filters, page{After: info.EndCursor})
+		assert.Error(t, err)
+	})
+}
@@ -1,11 +1,24 @@
 package api
 
-import "github.com/phyrwork/bogglr/pkg/database"
+import (
+	"sync"
+
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/phyrwork/bogglr/pkg/pubsub"
+)
 
 // This file will not be regenerated automatically.
 //
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	DB *database.DB
+	DB     *database.DB
+	Dict   boggle.Lexicon
+	Scorer boggle.Scorer
+	Broker pubsub.Broker
+	// SessionKey signs the tokens of sessions.
+	SessionKey []byte
+
+	dicts sync.Map // Dictionary ID to *boggle.DAWG.
 }
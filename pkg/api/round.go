@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// now is the server time by which rounds are started, finished and
+// checked for submissions.
+var now = time.Now
+
+func checkDuration(duration *int) error {
+	if duration != nil && *duration <= 0 {
+		return errorf(CodeInvalidArgument, "invalid duration %d: must be positive", *duration).withField("duration")
+	}
+	return nil
+}
+
+// updateGame applies f to a game in a transaction that holds a lock on
+// the game, saving it if f succeeds.
+func (r *Resolver) updateGame(ctx context.Context, id string, f func(*gorm.DB, *database.Game) error) (*model.Game, error) {
+	gameID, err := DecodeID(NodeGame, id)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		record database.Game
+		update error
+	)
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, gameID).Error; err != nil {
+			return err
+		}
+		if update = f(tx, &record); update != nil {
+			return update
+		}
+		return tx.Save(&record).Error
+	})
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, errorf(CodeNotFound, "game '%s' not found", id)
+	case update != nil:
+		return nil, update
+	case err != nil:
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	obj := gameOf(record)
+	return &obj, nil
+}
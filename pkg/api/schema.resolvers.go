@@ -7,14 +7,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
-	"strconv"
-	"strings"
+	"math/rand"
+	"sort"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/phyrwork/bogglr/pkg/api/generated"
 	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
 	"github.com/phyrwork/bogglr/pkg/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (r *gameResolver) Board(ctx context.Context, obj *model.Game) ([]string, error) {
@@ -27,87 +29,542 @@ func (r *gameResolver) Board(ctx context.Context, obj *model.Game) ([]string, er
 	return obj.Board, nil
 }
 
-func (r *mutationResolver) CreatePlayer(ctx context.Context, name string) (*model.Player, error) {
+func (r *gameResolver) Neighbours(ctx context.Context, obj *model.Game, point model.Point) ([]model.Point, error) {
+	topology, err := gameTopology(obj)
+	if err != nil {
+		return nil, err
+	}
+	board, err := r.Game().Board(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	tiles, err := model.Board(board).Dump()
+	if err != nil {
+		return nil, fmt.Errorf("invalid board: %w", err)
+	}
+	return MapOf(topology.Neighbours(tiles, boggle.Point(point)), func(point boggle.Point) model.Point {
+		return model.Point(point)
+	}), nil
+}
+
+func (r *gameResolver) Dictionary(ctx context.Context, obj *model.Game) (*model.Dictionary, error) {
+	if obj.DictionaryID == nil {
+		return nil, nil
+	}
+	return r.Query().Dictionary(ctx, GlobalID(NodeDictionary, *obj.DictionaryID))
+}
+
+func (r *gameResolver) Solutions(ctx context.Context, obj *model.Game) ([]*model.Solution, error) {
+	dict, err := r.gameDict(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	if dict == nil {
+		return nil, errorf(CodeFailedPrecondition, "no dictionary available to solve game")
+	}
+	board, err := r.Game().Board(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	tiles, err := model.Board(board).Dump()
+	if err != nil {
+		return nil, fmt.Errorf("invalid board: %w", err)
+	}
+	topology, err := gameTopology(obj)
+	if err != nil {
+		return nil, err
+	}
+	solutions, err := boggle.Solver{Topology: topology}.Solve(ctx, tiles, dict)
+	if err != nil {
+		return nil, err
+	}
+	return MapPointersOf(solutions, func(solution boggle.Solution) model.Solution {
+		return model.Solution{
+			Word: solution.Word,
+			Paths: MapOf(solution.Paths, func(path boggle.Path) []model.Point {
+				return MapOf(path, func(point boggle.Point) model.Point {
+					return model.Point(point)
+				})
+			}),
+			Score: r.scorer().Score(solution.Word),
+		}
+	}), nil
+}
+
+func (r *gameResolver) Scores(ctx context.Context, obj *model.Game) ([]*model.PlayerScore, error) {
+	id, err := DecodeID(NodeGame, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := r.gameScores(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(scores) == 0 {
+		return []*model.PlayerScore{}, nil
+	}
+	var records []database.Player
+	if err := r.DB.WithContext(ctx).Find(&records, KeysOf(scores)).Error; err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if si, sj := scores[records[i].ID], scores[records[j].ID]; si != sj {
+			return si > sj
+		}
+		return records[i].ID < records[j].ID
+	})
+	return MapPointersOf(records, func(record database.Player) model.PlayerScore {
+		player := playerOf(record)
+		return model.PlayerScore{
+			Player: &player,
+			Score:  scores[record.ID],
+		}
+	}), nil
+}
+
+func (r *gameResolver) InviteCode(ctx context.Context, obj *model.Game) (*string, error) {
+	if obj.InviteCode == nil {
+		return nil, nil
+	}
+	playerID, err := caller(ctx)
+	if err != nil {
+		return nil, nil
+	}
+	id, err := DecodeID(NodeGame, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	member, err := membership(r.DB.WithContext(ctx), id, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	if member == nil {
+		return nil, nil
+	}
+	return obj.InviteCode, nil
+}
+
+func (r *gameResolver) Players(ctx context.Context, obj *model.Game) ([]*model.GamePlayer, error) {
+	id, err := DecodeID(NodeGame, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	var records []database.GamePlayer
+	err = r.DB.WithContext(ctx).
+		Preload("Player").
+		Where("game_id = ?", id).
+		Order("joined_at, player_id").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return MapPointersOf(records, func(record database.GamePlayer) model.GamePlayer {
+		player := playerOf(*record.Player)
+		return model.GamePlayer{
+			Player:   &player,
+			Role:     model.GameRole(record.Role),
+			JoinedAt: record.JoinedAt,
+		}
+	}), nil
+}
+
+func (r *gameEventResolver) Game(ctx context.Context, obj *model.GameEvent) (*model.Game, error) {
+	return r.Query().Game(ctx, obj.GameID)
+}
+
+func (r *gameEventResolver) Word(ctx context.Context, obj *model.GameEvent) (*model.Word, error) {
+	if obj.WordID == nil {
+		return nil, nil
+	}
+	return r.word(ctx, *obj.WordID)
+}
+
+func (r *gameEventResolver) Player(ctx context.Context, obj *model.GameEvent) (*model.Player, error) {
+	if obj.PlayerID == nil {
+		return nil, nil
+	}
+	return r.Query().Player(ctx, *obj.PlayerID)
+}
+
+func (r *gameEventResolver) Scores(ctx context.Context, obj *model.GameEvent) ([]*model.PlayerScore, error) {
+	if obj.Type != model.GameEventTypeRoundFinished {
+		return nil, nil
+	}
+	game, err := r.Query().Game(ctx, obj.GameID)
+	if err != nil {
+		return nil, err
+	}
+	return r.Resolver.Game().Scores(ctx, game)
+}
+
+func (r *mutationResolver) CreatePlayer(ctx context.Context, name string, password *string) (*model.Player, error) {
 	record := database.Player{Name: name}
+	if password != nil {
+		if err := checkPassword(*password); err != nil {
+			return nil, err
+		}
+		if err := record.SetPassword(*password); err != nil {
+			return nil, errorf(CodeInvalidArgument, "invalid password: %w", err).withField("password")
+		}
+	}
 	if err := r.DB.WithContext(ctx).Create(&record).Error; err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return &model.Player{
-		ID:   strconv.Itoa(record.ID),
-		Name: record.Name,
-	}, nil
+	obj := playerOf(record)
+	return &obj, nil
 }
 
-func (r *mutationResolver) CreateGame(ctx context.Context, board []string) (*model.Game, error) {
-	tiles := model.Board(board).Dump()
-	if !tiles.IsRect() {
+func (r *mutationResolver) Login(ctx context.Context, name string, password string) (*model.Session, error) {
+	var player database.Player
+	err := r.DB.WithContext(ctx).Where("name = ? AND password_hash IS NOT NULL", name).First(&player).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	// A player that is not found has no password, which fails the check.
+	if !player.CheckPassword(password) {
+		return nil, errorf(CodeUnauthenticated, "invalid name or password")
+	}
+	record := database.Session{PlayerID: player.ID, ExpiresAt: now().Add(SessionDuration)}
+	if err := r.DB.WithContext(ctx).Create(&record).Error; err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	token, err := r.signToken(record.ID)
+	if err != nil {
+		return nil, err
+	}
+	obj := playerOf(player)
+	return &model.Session{Token: token, Player: &obj, ExpiresAt: record.ExpiresAt}, nil
+}
+
+func (r *mutationResolver) Logout(ctx context.Context) (bool, error) {
+	session := sessionOf(ctx)
+	if session == nil {
+		return false, nil
+	}
+	result := r.DB.WithContext(ctx).Delete(&database.Session{}, session.ID)
+	if result.Error != nil {
+		return false, fmt.Errorf("database error: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *mutationResolver) CreateGame(ctx context.Context, board []string, topology *string, duration *int, dictionaryID *string) (*model.Game, error) {
+	tiles, err := model.Board(board).Dump()
+	if err != nil {
+		return nil, errorf(CodeInvalidArgument, "invalid board: %w", err).withField("board")
+	}
+	if tiles.Size()[boggle.X] > database.MaxBoardSize {
 		w, h := tiles.Dims()
-		return nil, fmt.Errorf("board must be rectangular: is %d x %v", w, h)
+		return nil, errorf(CodeBoardTooLarge, "board is too wide: is %d x %v", w, h).withField("board")
+	}
+	if err := checkDuration(duration); err != nil {
+		return nil, err
 	}
 	var record database.Game
-	record.Board = board
+	record.LoadBoard(tiles)
+	record.Duration = duration
+	if record.Topology, err = topologyName(topology); err != nil {
+		return nil, err
+	}
+	if record.DictionaryID, err = r.dictionaryID(ctx, dictionaryID); err != nil {
+		return nil, err
+	}
 	if err := r.DB.WithContext(ctx).Create(&record).Error; err != nil {
+		w, h := tiles.Dims()
 		switch {
-		case strings.Contains(err.Error(), "value too long"): // TODO: be more specific.
-			w, h := tiles.Dims()
-			return nil, fmt.Errorf("board is too wide: is %d x %v", w, h)
-		case strings.Contains(err.Error(), "violates check"): // TODO: be more specific.
-			w, h := tiles.Dims()
-			return nil, fmt.Errorf("board is too tall: is %d x %v", w, h)
+		case isPgError(err, pgStringDataRightTruncation, ""):
+			return nil, errorf(CodeBoardTooLarge, "board is too wide: is %d x %v", w, h).withField("board")
+		case isPgError(err, pgCheckViolation, boardConstraint):
+			return nil, errorf(CodeBoardTooLarge, "board is too tall: is %d x %v", w, h).withField("board")
 		default:
 			return nil, fmt.Errorf("database error: %w", err)
 		}
 	}
-	obj := model.Game{
-		ID:    strconv.Itoa(int(record.ID)),
-		Board: board,
+	obj := gameOf(record)
+	return &obj, nil
+}
+
+func (r *mutationResolver) GenerateGame(ctx context.Context, diceSet string, seed *int, topology *string, duration *int, dictionaryID *string) (*model.Game, error) {
+	set, ok := boggle.DiceSets[diceSet]
+	if !ok {
+		return nil, errorf(CodeInvalidArgument, "unknown dice set '%s'", diceSet).withField("diceSet")
+	}
+	if err := checkDuration(duration); err != nil {
+		return nil, err
+	}
+	var value int64
+	if seed != nil {
+		value = int64(*seed)
+	} else {
+		value = int64(rand.Int31())
+	}
+	var (
+		record database.Game
+		err    error
+	)
+	record.LoadBoard(set.Roll(rand.New(rand.NewSource(value))))
+	record.DiceSet = &diceSet
+	record.Seed = &value
+	record.Duration = duration
+	if record.Topology, err = topologyName(topology); err != nil {
+		return nil, err
+	}
+	if record.DictionaryID, err = r.dictionaryID(ctx, dictionaryID); err != nil {
+		return nil, err
 	}
+	if err := r.DB.WithContext(ctx).Create(&record).Error; err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	obj := gameOf(record)
 	return &obj, nil
 }
 
-func (r *mutationResolver) CreateWord(ctx context.Context, gameID string, path []model.Point) (*model.Word, error) {
-	game, err := r.Query().Game(ctx, gameID)
+func (r *mutationResolver) StartGame(ctx context.Context, id string, duration *int) (*model.Game, error) {
+	if err := checkDuration(duration); err != nil {
+		return nil, err
+	}
+	game, err := r.updateGame(ctx, id, func(tx *gorm.DB, record *database.Game) error {
+		if err := checkHost(ctx, tx, record); err != nil {
+			return fmt.Errorf("cannot start game '%s': %w", id, err)
+		}
+		if duration != nil {
+			record.Duration = duration
+		}
+		if err := record.Start(now()); err != nil {
+			return fmt.Errorf("cannot start game '%s': %w", id, err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	var record database.Word
-	record.GameID, err = strconv.Atoi(game.ID)
-	record.Path = MapOf(path, func(point model.Point) database.Point {
-		return database.Point(point)
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundStarted, GameID: game.ID, EndsAt: game.EndsAt})
+	return game, nil
+}
+
+func (r *mutationResolver) FinishGame(ctx context.Context, id string) (*model.Game, error) {
+	game, err := r.updateGame(ctx, id, func(tx *gorm.DB, record *database.Game) error {
+		if err := checkHost(ctx, tx, record); err != nil {
+			return fmt.Errorf("cannot finish game '%s': %w", id, err)
+		}
+		if err := record.Finish(now()); err != nil {
+			return fmt.Errorf("cannot finish game '%s': %w", id, err)
+		}
+		return nil
 	})
-	if err := r.DB.WithContext(ctx).Create(&record).Error; err != nil {
+	if err != nil {
+		return nil, err
+	}
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundFinished, GameID: game.ID})
+	return game, nil
+}
+
+func (r *mutationResolver) CreateLobby(ctx context.Context, gameID string) (*model.Game, error) {
+	playerID, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	game, err := r.updateGame(ctx, gameID, func(tx *gorm.DB, record *database.Game) error {
+		if status := record.Status(now()); status != database.GameCreated {
+			return fmt.Errorf("cannot create lobby for game '%s': %w", gameID, &database.StatusError{Status: status})
+		}
+		if record.InviteCode != nil {
+			return errorf(CodeConflict, "cannot create lobby for game '%s': game has a lobby", gameID)
+		}
+		code, err := database.NewInviteCode()
+		if err != nil {
+			return fmt.Errorf("invite code error: %w", err)
+		}
+		record.InviteCode = &code
+		host := database.GamePlayer{GameID: record.ID, PlayerID: playerID, Role: database.RoleHost, JoinedAt: now()}
+		if err := tx.Create(&host).Error; err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	player := GlobalID(NodePlayer, playerID)
+	r.publish(ctx, model.GameEvent{Type: model.GameEventTypePlayerJoined, GameID: game.ID, PlayerID: &player})
+	return game, nil
+}
+
+func (r *mutationResolver) JoinGame(ctx context.Context, inviteCode string, role *model.GameRole) (*model.Game, error) {
+	playerID, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value := database.RolePlayer
+	if role != nil {
+		value = database.GameRole(*role)
+	}
+	if value.IsHost() {
+		return nil, errorf(CodeInvalidArgument, "cannot join game as host").withField("role")
+	}
+	var record database.Game
+	err = r.DB.WithContext(ctx).Where("invite_code = ?", inviteCode).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorf(CodeNotFound, "invite code '%s' not found", inviteCode)
+	} else if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return &model.Word{
-		ID:   strconv.Itoa(record.ID),
-		Game: game,
-		Path: path,
-	}, nil
+	if status := record.Status(now()); status == database.GameFinished {
+		return nil, fmt.Errorf("cannot join game: %w", &database.StatusError{Status: status})
+	}
+	member := database.GamePlayer{GameID: record.ID, PlayerID: playerID, Role: value, JoinedAt: now()}
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&member)
+	if result.Error != nil {
+		return nil, fmt.Errorf("database error: %w", result.Error)
+	}
+	joined := result.RowsAffected > 0
+	if !joined {
+		// The host keeps hosting.
+		hosting := value.Hosting()
+		err = r.DB.WithContext(ctx).
+			Model(&database.GamePlayer{}).
+			Where("game_id = ? AND player_id = ?", record.ID, playerID).
+			Update("role", gorm.Expr("CASE WHEN role IN ? THEN ? ELSE ? END",
+				[]database.GameRole{database.RoleHost, database.RoleSpectatingHost}, hosting, value)).Error
+		if err != nil {
+			return nil, fmt.Errorf("database error: %w", err)
+		}
+	}
+	game := gameOf(record)
+	if joined {
+		player := GlobalID(NodePlayer, playerID)
+		r.publish(ctx, model.GameEvent{Type: model.GameEventTypePlayerJoined, GameID: game.ID, PlayerID: &player})
+	}
+	return &game, nil
+}
+
+func (r *mutationResolver) LeaveGame(ctx context.Context, gameID string) (bool, error) {
+	playerID, err := caller(ctx)
+	if err != nil {
+		return false, err
+	}
+	id, err := DecodeID(NodeGame, gameID)
+	if err != nil {
+		return false, err
+	}
+	var left, finished bool
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var record database.Game
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&record, id).Error; err != nil {
+			return err
+		}
+		running := record.Status(now()) == database.GameRunning
+		if left, err = leaveGame(tx, &record, playerID); err != nil {
+			return err
+		}
+		finished = running && record.Status(now()) == database.GameFinished
+		return nil
+	})
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	if finished {
+		r.publish(ctx, model.GameEvent{Type: model.GameEventTypeRoundFinished, GameID: gameID})
+	}
+	return left, nil
+}
+
+func (r *mutationResolver) UploadDictionary(ctx context.Context, name string, language string, version string, format *model.DictionaryFormat, file graphql.Upload) (*model.Dictionary, error) {
+	if format == nil {
+		list := model.DictionaryFormatList
+		format = &list
+	}
+	dict, err := readDict(file.File, *format)
+	if err != nil {
+		return nil, errorf(CodeInvalidArgument, "invalid dictionary file '%s': %w", file.Filename, err).withField("file")
+	}
+	record := database.Dictionary{
+		Name:     name,
+		Language: language,
+		Version:  version,
+	}
+	record.LoadDict(dict)
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Words").Create(&record).Error; err != nil {
+			return err
+		}
+		for i := range record.Words {
+			record.Words[i].DictionaryID = record.ID
+		}
+		return tx.CreateInBatches(record.Words, 1000).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	r.dicts.Store(record.ID, boggle.NewDAWG(dict))
+	obj := dictionaryOf(record)
+	return &obj, nil
+}
+
+func (r *mutationResolver) SubmitWord(ctx context.Context, gameID string, path []model.Point, text *string) (*model.WordSubmission, error) {
+	return r.submitWord(ctx, gameID, path, text)
+}
+
+func (r *mutationResolver) CreateWord(ctx context.Context, gameID string, path []model.Point, text *string) (*model.Word, error) {
+	submission, err := r.submitWord(ctx, gameID, path, text)
+	if err != nil {
+		return nil, err
+	}
+	return submission.Word, nil
 }
 
 func (r *playerResolver) Words(ctx context.Context, obj *model.Player) ([]*model.Word, error) {
-	var (
-		record database.Player
-		err    error
-	)
-	record.ID, err = strconv.Atoi(obj.ID)
+	id, err := DecodeID(NodePlayer, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid word id '%s': %w", obj.ID, err)
+		return nil, err
 	}
-	err = r.DB.WithContext(ctx).Preload("Words").Find(&record).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, fmt.Errorf("player '%s' not found", obj.ID)
-	} else if err != nil {
+	records, err := r.loaders(ctx).PlayerWords.Load(id)
+	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return MapPointersOf(record.Words, func(record database.Word) model.Word {
-		return model.Word{
-			ID: strconv.Itoa(record.ID),
-			Path: MapOf(record.Path, func(record database.Point) model.Point {
-				return model.Point(record)
-			}),
+	return MapPointersOf(records, wordOf), nil
+}
+
+func (r *playerResolver) Score(ctx context.Context, obj *model.Player, gameID string) (int, error) {
+	playerID, err := DecodeID(NodePlayer, obj.ID)
+	if err != nil {
+		return 0, err
+	}
+	id, err := DecodeID(NodeGame, gameID)
+	if err != nil {
+		return 0, err
+	}
+	scores, err := r.gameScores(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	return scores[playerID], nil
+}
+
+func (r *queryResolver) Me(ctx context.Context) (*model.Player, error) {
+	session := sessionOf(ctx)
+	if session == nil {
+		return nil, nil
+	}
+	return r.Query().Player(ctx, GlobalID(NodePlayer, session.PlayerID))
+}
+
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	return r.node(ctx, id)
+}
+
+func (r *queryResolver) Nodes(ctx context.Context, ids []string) ([]model.Node, error) {
+	nodes := make([]model.Node, len(ids))
+	for i, id := range ids {
+		var err error
+		if nodes[i], err = r.node(ctx, id); err != nil {
+			return nil, err
 		}
-	}), nil
+	}
+	return nodes, nil
 }
 
 func (r *queryResolver) Player(ctx context.Context, id string) (*model.Player, error) {
@@ -115,24 +572,32 @@ func (r *queryResolver) Player(ctx context.Context, id string) (*model.Player, e
 		record database.Player
 		err    error
 	)
-	record.ID, err = strconv.Atoi(id)
+	record.ID, err = DecodeID(NodePlayer, id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid player id '%s': %w", id, err)
+		return nil, err
 	}
 	err = r.DB.WithContext(ctx).First(&record).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("player '%s' not found", id)
+		return nil, errorf(CodeNotFound, "player '%s' not found", id)
 	} else if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return &model.Player{
-		ID:   strconv.Itoa(record.ID),
-		Name: record.Name,
-	}, nil
+	obj := playerOf(record)
+	return &obj, nil
 }
 
-func (r *queryResolver) Players(ctx context.Context, first *int, after *string) (*model.PlayersConnection, error) {
-	panic(fmt.Errorf("not implemented"))
+func (r *queryResolver) Players(ctx context.Context, first *int, after *string, last *int, before *string) (*model.PlayersConnection, error) {
+	edges, pageInfo, err := paginate[database.Player](ctx, r.DB, nil, page{first, after, last, before})
+	if err != nil {
+		return nil, err
+	}
+	return &model.PlayersConnection{
+		Edges: MapPointersOf(edges, func(e edge[database.Player]) model.PlayersEdge {
+			player := playerOf(e.Node)
+			return model.PlayersEdge{Cursor: e.Cursor, Node: &player}
+		}),
+		PageInfo: pageInfo,
+	}, nil
 }
 
 func (r *queryResolver) Game(ctx context.Context, id string) (*model.Game, error) {
@@ -140,121 +605,150 @@ func (r *queryResolver) Game(ctx context.Context, id string) (*model.Game, error
 		record database.Game
 		err    error
 	)
-	record.ID, err = strconv.Atoi(id)
+	record.ID, err = DecodeID(NodeGame, id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid game id '%s': %w", id, err)
+		return nil, err
 	}
 	err = r.DB.WithContext(ctx).First(&record).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, fmt.Errorf("game '%s' not found", id)
+		return nil, errorf(CodeNotFound, "game '%s' not found", id)
 	} else if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return &model.Game{
-		ID:    strconv.Itoa(record.ID),
-		Board: record.Board,
+	obj := gameOf(record)
+	return &obj, nil
+}
+
+func (r *queryResolver) Games(ctx context.Context, first *int, after *string, last *int, before *string) (*model.GamesConnection, error) {
+	edges, pageInfo, err := paginate[database.Game](ctx, r.DB, nil, page{first, after, last, before})
+	if err != nil {
+		return nil, err
+	}
+	return &model.GamesConnection{
+		Edges: MapPointersOf(edges, func(e edge[database.Game]) model.GamesEdge {
+			game := gameOf(e.Node)
+			return model.GamesEdge{Cursor: e.Cursor, Node: &game}
+		}),
+		PageInfo: pageInfo,
 	}, nil
 }
 
-func (r *queryResolver) Games(ctx context.Context, first *int, after *string) (*model.GamesConnection, error) {
-	qry := r.DB.WithContext(ctx)
-	if after != nil {
-		startCursor, err := strconv.Atoi(*after)
+func (r *queryResolver) Words(ctx context.Context, gameID *string, playerID *string, text *string, first *int, after *string, last *int, before *string) (*model.WordsConnection, error) {
+	var filters []func(*gorm.DB) *gorm.DB
+	if gameID != nil {
+		id, err := DecodeID(NodeGame, *gameID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid start cursor '%s': %w", *after, err)
+			return nil, err
 		}
-		qry = qry.Where("id > ?", startCursor)
+		filters = append(filters, func(qry *gorm.DB) *gorm.DB {
+			return qry.Where("game_id = ?", id)
+		})
 	}
-	if first != nil {
-		qry = qry.Limit(*first)
+	if playerID != nil {
+		id, err := DecodeID(NodePlayer, *playerID)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, func(qry *gorm.DB) *gorm.DB {
+			return qry.Where("id IN (?)", r.DB.Model(&database.WordPlayer{}).
+				Select("word_id").
+				Where("player_id = ?", id))
+		})
 	}
-	qry = qry.Order("id asc")
-
-	var records []database.Game
-	if err := qry.Find(&records).Error; err != nil {
-		return nil, fmt.Errorf("database error: %w", err)
+	if text != nil {
+		filters = append(filters, func(qry *gorm.DB) *gorm.DB {
+			return qry.Where("text = ?", *text)
+		})
 	}
-	var edges []*model.Game = MapPointersOf(records, func(record database.Game) model.Game {
-		return model.Game{
-			ID:    strconv.Itoa(int(record.ID)),
-			Board: record.Board,
-		}
-	})
-	if len(edges) == 0 {
-		return nil, nil
+
+	edges, pageInfo, err := paginate[database.Word](ctx, r.DB, filters, page{first, after, last, before})
+	if err != nil {
+		return nil, err
 	}
+	return &model.WordsConnection{
+		Edges: MapPointersOf(edges, func(e edge[database.Word]) model.WordsEdge {
+			word := wordOf(e.Node)
+			return model.WordsEdge{Cursor: e.Cursor, Node: &word}
+		}),
+		PageInfo: pageInfo,
+	}, nil
+}
 
-	pageInfo := model.PageInfo{
-		StartCursor: edges[0].ID,
-		EndCursor:   edges[len(edges)-1].ID,
+func (r *queryResolver) Dictionary(ctx context.Context, id string) (*model.Dictionary, error) {
+	var (
+		record database.Dictionary
+		err    error
+	)
+	record.ID, err = DecodeID(NodeDictionary, id)
+	if err != nil {
+		return nil, err
 	}
-	qry = r.DB.WithContext(ctx).
-		Where("id > ?", records[len(records)-1].ID).
-		Limit(1)
-	if err := qry.Find(&records).Error; err != nil {
-		log.Print(fmt.Errorf("database error: %w", err))
-	} else {
-		hasNextPage := len(records) > 0
-		pageInfo.HasNextPage = &hasNextPage
+	err = r.DB.WithContext(ctx).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errorf(CodeNotFound, "dictionary '%s' not found", id)
+	} else if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
 	}
+	obj := dictionaryOf(record)
+	return &obj, nil
+}
 
-	return &model.GamesConnection{
-		Edges:    edges,
-		PageInfo: &pageInfo,
+func (r *queryResolver) Dictionaries(ctx context.Context, first *int, after *string, last *int, before *string) (*model.DictionariesConnection, error) {
+	edges, pageInfo, err := paginate[database.Dictionary](ctx, r.DB, nil, page{first, after, last, before}, "name", "version")
+	if err != nil {
+		return nil, err
+	}
+	return &model.DictionariesConnection{
+		Edges: MapPointersOf(edges, func(e edge[database.Dictionary]) model.DictionariesEdge {
+			dictionary := dictionaryOf(e.Node)
+			return model.DictionariesEdge{Cursor: e.Cursor, Node: &dictionary}
+		}),
+		PageInfo: pageInfo,
 	}, nil
 }
 
-func (r *queryResolver) Words(ctx context.Context, gameID *string, playerID *string, first *int, after *string) (*model.WordsConnection, error) {
-	panic(fmt.Errorf("not implemented"))
+func (r *subscriptionResolver) GameEvents(ctx context.Context, gameID string) (<-chan *model.GameEvent, error) {
+	game, err := r.Query().Game(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return r.gameEvents(ctx, game)
 }
 
 func (r *wordResolver) Game(ctx context.Context, obj *model.Word) (*model.Game, error) {
-	var (
-		record database.Word
-		err    error
-	)
-	record.ID, err = strconv.Atoi(obj.ID)
+	id, err := DecodeID(NodeWord, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid word id '%s': %w", obj.ID, err)
+		return nil, err
 	}
-	err = r.DB.WithContext(ctx).Preload("Game").Find(&record).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, fmt.Errorf("word '%s' not found", obj.ID)
-	} else if err != nil {
+	record, err := r.loaders(ctx).WordGame.Load(id)
+	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return &model.Game{
-		ID:    strconv.Itoa(record.Game.ID),
-		Board: record.Game.Board,
-	}, nil
+	if record == nil {
+		return nil, errorf(CodeNotFound, "word '%s' not found", obj.ID)
+	}
+	game := gameOf(*record)
+	return &game, nil
 }
 
 func (r *wordResolver) Players(ctx context.Context, obj *model.Word) ([]*model.Player, error) {
-	var (
-		record database.Word
-		err    error
-	)
-	record.ID, err = strconv.Atoi(obj.ID)
+	id, err := DecodeID(NodeWord, obj.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid word id '%s': %w", obj.ID, err)
+		return nil, err
 	}
-	err = r.DB.WithContext(ctx).Preload("Players").Find(&record).Error
-	if err == gorm.ErrRecordNotFound {
-		return nil, fmt.Errorf("word '%s' not found", obj.ID)
-	} else if err != nil {
+	records, err := r.loaders(ctx).WordPlayers.Load(id)
+	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
-	return MapPointersOf(record.Players, func(record database.Player) model.Player {
-		return model.Player{
-			ID:   strconv.Itoa(record.ID),
-			Name: record.Name,
-		}
-	}), nil
+	return MapPointersOf(records, playerOf), nil
 }
 
 // Game returns generated.GameResolver implementation.
 func (r *Resolver) Game() generated.GameResolver { return &gameResolver{r} }
 
+// GameEvent returns generated.GameEventResolver implementation.
+func (r *Resolver) GameEvent() generated.GameEventResolver { return &gameEventResolver{r} }
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -264,11 +758,16 @@ func (r *Resolver) Player() generated.PlayerResolver { return &playerResolver{r}
 // Query returns generated.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
 // Word returns generated.WordResolver implementation.
 func (r *Resolver) Word() generated.WordResolver { return &wordResolver{r} }
 
 type gameResolver struct{ *Resolver }
+type gameEventResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type playerResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 type wordResolver struct{ *Resolver }
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryResolver_Words_Text(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		game := database.Game{Board: database.Board{"cat", "xyz"}}
+		if err := tx.WithContext(ctx).Create(&game).Error; err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		words := []database.Word{
+			{GameID: game.ID, Path: database.Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cat"},
+			{GameID: game.ID, Path: database.Path{{2, 0}, {1, 0}, {0, 0}}, Text: "tac"},
+		}
+		if err := tx.WithContext(ctx).Create(&words).Error; err != nil {
+			t.Fatalf("create words error: %v", err)
+		}
+		r := &Resolver{DB: tx}
+
+		gameID, text := GlobalID(NodeGame, game.ID), "cat"
+		conn, err := r.Query().Words(ctx, &gameID, nil, &text, nil, nil, nil, nil)
+		if assert.NoError(t, err) && assert.Len(t, conn.Edges, 1) {
+			assert.Equal(t, GlobalID(NodeWord, words[0].ID), conn.Edges[0].Node.ID)
+			assert.Equal(t, "cat", conn.Edges[0].Node.Text)
+		}
+	})
+}
+
+func TestQueryResolver_Words(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		players := []database.Player{{Name: "alice"}, {Name: "bob"}}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		alice, bob := players[0], players[1]
+		games := []database.Game{{Board: database.Board{"cat", "xyz"}}, {Board: database.Board{"dog", "xyz"}}}
+		if err := tx.WithContext(ctx).Create(&games).Error; err != nil {
+			t.Fatalf("create games error: %v", err)
+		}
+		words := []database.Word{
+			{GameID: games[0].ID, Path: database.Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cat", Players: []database.Player{alice}},
+			{GameID: games[0].ID, Path: database.Path{{2, 0}, {1, 0}, {0, 0}}, Text: "tac", Players: []database.Player{alice, bob}},
+			{GameID: games[0].ID, Path: database.Path{{0, 0}, {1, 0}}, Text: "ca", Players: []database.Player{bob}},
+			{GameID: games[1].ID, Path: database.Path{{0, 0}, {1, 0}, {2, 0}}, Text: "dog", Players: []database.Player{alice}},
+		}
+		if err := tx.WithContext(ctx).Create(&words).Error; err != nil {
+			t.Fatalf("create words error: %v", err)
+		}
+		r := &Resolver{DB: tx}
+		ids := func(words ...database.Word) []string {
+			return MapOf(words, func(word database.Word) string {
+				return GlobalID(NodeWord, word.ID)
+			})
+		}
+
+		gameID, playerID, one := GlobalID(NodeGame, games[0].ID), GlobalID(NodePlayer, alice.ID), 1
+		conn, err := r.Query().Words(ctx, &gameID, &playerID, nil, nil, nil, nil, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, ids(words[0], words[1]), MapOf(conn.Edges, func(edge *model.WordsEdge) string {
+				return edge.Node.ID
+			}))
+			assert.Equal(t, 2, conn.PageInfo.TotalCount)
+		}
+
+		conn, err = r.Query().Words(ctx, &gameID, &playerID, nil, &one, nil, nil, nil)
+		if assert.NoError(t, err) && assert.Len(t, conn.Edges, 1) {
+			assert.Equal(t, ids(words[0]), []string{conn.Edges[0].Node.ID})
+			assert.True(t, *conn.PageInfo.HasNextPage)
+		}
+		conn, err = r.Query().Words(ctx, &gameID, &playerID, nil, &one, conn.PageInfo.EndCursor, nil, nil)
+		if assert.NoError(t, err) && assert.Len(t, conn.Edges, 1) {
+			assert.Equal(t, ids(words[1]), []string{conn.Edges[0].Node.ID})
+			assert.False(t, *conn.PageInfo.HasNextPage)
+		}
+
+		invalid := "x"
+		_, err = r.Query().Words(ctx, &invalid, nil, nil, nil, nil, nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestGameResolver_Solutions_NoDict(t *testing.T) {
+	r := &Resolver{}
+	_, err := r.Game().Solutions(context.Background(), &model.Game{Board: model.Board{"cat"}})
+	var e *Error
+	if assert.ErrorAs(t, err, &e) {
+		assert.Equal(t, CodeFailedPrecondition, e.Code)
+	}
+}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+)
+
+func (r *Resolver) scorer() boggle.Scorer {
+	if r.Scorer == nil {
+		return boggle.ClassicTable
+	}
+	return r.Scorer
+}
+
+// gameScores returns the score of each player that has found a word in a game.
+func (r *Resolver) gameScores(ctx context.Context, gameID int) (map[int]int, error) {
+	var rows []struct {
+		PlayerID int
+		Text     string
+	}
+	err := r.DB.WithContext(ctx).
+		Model(&database.WordPlayer{}).
+		Select("word_players.player_id, words.text").
+		Joins("JOIN words ON words.id = word_players.word_id").
+		Where("words.game_id = ?", gameID).
+		Group("word_players.player_id, words.text").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	found := make(map[int][]string)
+	for _, row := range rows {
+		found[row.PlayerID] = append(found[row.PlayerID], row.Text)
+	}
+	return boggle.Scores(r.scorer(), found), nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// submitWord finds or creates the word spelled by a path in a game and
+// links it to the player that makes the request. The game is locked
+// while the word is submitted, so that the round cannot end between the
+// check that it is running and the submission.
+func (r *Resolver) submitWord(ctx context.Context, gameID string, path []model.Point, text *string) (*model.WordSubmission, error) {
+	playerID, err := caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var record database.Word
+	if record.GameID, err = DecodeID(NodeGame, gameID); err != nil {
+		return nil, err
+	}
+	record.Path = MapOf(path, func(point model.Point) database.Point {
+		return database.Point(point)
+	})
+
+	var (
+		game            model.Game
+		created, linked bool
+		rejected        error
+	)
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var gameRecord database.Game
+		if err := tx.Clauses(clause.Locking{Strength: "SHARE"}).First(&gameRecord, record.GameID).Error; err != nil {
+			return err
+		}
+		game = gameOf(gameRecord)
+		if rejected = r.checkSubmission(ctx, tx, &gameRecord, playerID); rejected != nil {
+			return rejected
+		}
+		if record.Text, rejected = r.validateClaim(ctx, &game, path, text); rejected != nil {
+			return rejected
+		}
+
+		// Each word that a path with wildcards may spell is a word of
+		// its own.
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "game_id"}, {Name: "path"}, {Name: "text"}},
+			DoNothing: true,
+		}).Create(&record)
+		if result.Error != nil {
+			return result.Error
+		}
+		if created = result.RowsAffected > 0; !created {
+			if err := tx.Where("game_id = ? AND path = ? AND text = ?", record.GameID, record.Path, record.Text).First(&record).Error; err != nil {
+				return err
+			}
+		}
+		result = tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&database.WordPlayer{WordID: record.ID, PlayerID: playerID})
+		linked = result.RowsAffected > 0
+		return result.Error
+	})
+	switch {
+	case rejected != nil:
+		return nil, rejected
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, errorf(CodeNotFound, "game '%s' not found", gameID)
+	case err != nil:
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	obj := wordOf(record)
+	obj.Game = &game
+	if linked {
+		player := GlobalID(NodePlayer, playerID)
+		r.publish(ctx, model.GameEvent{Type: model.GameEventTypeWordSubmitted, GameID: game.ID, WordID: &obj.ID, PlayerID: &player})
+	}
+	return &model.WordSubmission{Word: &obj, New: created, Duplicate: !linked}, nil
+}
+
+// checkSubmission checks that a game is running and, if it is a lobby,
+// that the player is one of its players.
+func (r *Resolver) checkSubmission(ctx context.Context, tx *gorm.DB, game *database.Game, playerID int) error {
+	id := GlobalID(NodeGame, game.ID)
+	if status := game.Status(now()); status != database.GameRunning {
+		return fmt.Errorf("cannot submit word in game '%s': %w", id, &database.StatusError{Status: status})
+	}
+	if game.InviteCode != nil {
+		member, err := membership(tx, game.ID, playerID)
+		if err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+		if member == nil || !member.Role.CanSubmit() {
+			return errorf(CodeForbidden, "cannot submit word in game '%s': caller is not a player", id)
+		}
+	}
+	return nil
+}
+
+// validateClaim returns the word spelled by a path on the board of a
+// game, which must be the claimed text if one is given.
+func (r *Resolver) validateClaim(ctx context.Context, game *model.Game, path []model.Point, text *string) (string, error) {
+	tiles, err := game.Board.Dump()
+	if err != nil {
+		return "", fmt.Errorf("invalid board: %w", err)
+	}
+	dict, err := r.gameDict(ctx, game)
+	if err != nil {
+		return "", err
+	}
+	topology, err := gameTopology(game)
+	if err != nil {
+		return "", err
+	}
+	var claim string
+	if text != nil {
+		claim = *text
+	}
+	validator := boggle.Validator{Dict: dict, MinLength: boggle.MinLength, Topology: topology}
+	word, err := validator.ValidateClaim(tiles, MapOf(path, func(point model.Point) boggle.Point {
+		return boggle.Point(point)
+	}), claim)
+	if err != nil {
+		return "", PathError(err)
+	}
+	return word, nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/phyrwork/bogglr/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+// withCaller returns a context in which requests are made by a player.
+func withCaller(ctx context.Context, playerID int) context.Context {
+	return context.WithValue(ctx, sessionKey{}, &database.Session{PlayerID: playerID})
+}
+
+func TestSubmitWord(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *database.DB) {
+		dict := &boggle.Dict{}
+		dict.Insert("cat")
+		r := &Resolver{DB: tx, Dict: dict}
+
+		players := []database.Player{{Name: "alice"}, {Name: "bob"}}
+		if err := tx.WithContext(ctx).Create(&players).Error; err != nil {
+			t.Fatalf("create players error: %v", err)
+		}
+		alice, bob := withCaller(ctx, players[0].ID), withCaller(ctx, players[1].ID)
+
+		game, err := r.Mutation().CreateGame(ctx, []string{"cat", "xyz"}, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		path := []model.Point{{0, 0}, {1, 0}, {2, 0}}
+		_, err = r.Mutation().SubmitWord(alice, game.ID, path, nil)
+		assert.Error(t, err, "submitted word before round started")
+		if _, err = r.Mutation().StartGame(ctx, game.ID, nil); err != nil {
+			t.Fatalf("start game error: %v", err)
+		}
+
+		_, err = r.Mutation().SubmitWord(ctx, game.ID, path, nil)
+		assert.ErrorIs(t, err, errNotLoggedIn)
+
+		submission, err := r.Mutation().SubmitWord(alice, game.ID, path, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "cat", submission.Word.Text)
+			assert.True(t, submission.New)
+			assert.False(t, submission.Duplicate)
+		}
+		first := submission.Word.ID
+
+		submission, err = r.Mutation().SubmitWord(alice, game.ID, path, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, first, submission.Word.ID)
+			assert.False(t, submission.New)
+			assert.True(t, submission.Duplicate)
+		}
+
+		submission, err = r.Mutation().SubmitWord(bob, game.ID, path, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, first, submission.Word.ID)
+			assert.False(t, submission.New)
+			assert.False(t, submission.Duplicate)
+		}
+
+		found, err := r.Word().Players(ctx, submission.Word)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []*model.Player{
+			{ID: GlobalID(NodePlayer, players[0].ID), Name: "alice"},
+			{ID: GlobalID(NodePlayer, players[1].ID), Name: "bob"},
+		}, found)
+
+		if _, err = r.Mutation().FinishGame(ctx, game.ID); err != nil {
+			t.Fatalf("finish game error: %v", err)
+		}
+		_, err = r.Mutation().SubmitWord(bob, game.ID, path, nil)
+		var statusErr *database.StatusError
+		if assert.ErrorAs(t, err, &statusErr, "submitted word after round finished") {
+			assert.Equal(t, database.GameFinished, statusErr.Status)
+		}
+	})
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/phyrwork/bogglr/pkg/api/model"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+)
+
+func gameTopology(obj *model.Game) (boggle.Topology, error) {
+	return boggle.ParseTopology(obj.Topology)
+}
+
+// topologyName checks that the named topology exists. A nil name is the
+// default topology.
+func topologyName(name *string) (*string, error) {
+	if name == nil {
+		return nil, nil
+	}
+	if _, err := boggle.ParseTopology(*name); err != nil {
+		return nil, errorf(CodeInvalidArgument, "%w", err).withField("topology")
+	}
+	return name, nil
+}
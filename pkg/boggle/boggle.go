@@ -1,11 +1,13 @@
 package boggle
 
+import "fmt"
+
 const (
 	X = 0
 	Y = 1
 )
 
-type Board [][]rune // Y, X
+type Board [][]Tile // Y, X
 
 func (b Board) Dims() (int, []int) {
 	h := len(b)
@@ -41,6 +43,48 @@ func (b Board) Size() [2]int {
 	return [2]int{wmax, h}
 }
 
+func (b Board) Contains(p Point) bool {
+	if p.Y() < 0 || p.Y() >= len(b) {
+		return false
+	}
+	return p.X() >= 0 && p.X() < len(b[p.Y()])
+}
+
+func (b Board) At(p Point) Tile {
+	return b[p.Y()][p.X()]
+}
+
+func (b Board) hasWildcard() bool {
+	for _, row := range b {
+		for _, t := range row {
+			if t.IsWildcard() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (b Board) Points() []Point {
+	var q []Point
+	for y, row := range b {
+		for x := range row {
+			q = append(q, Point{x, y})
+		}
+	}
+	return q
+}
+
+// Neighbours returns the points adjacent to p, horizontally, vertically
+// or diagonally, that are on the board.
+func (b Board) Neighbours(p Point) []Point {
+	return Square.Neighbours(b, p)
+}
+
+func (b Board) IsAdjacent(p, q Point) bool {
+	return IsAdjacent(Square, b, p, q)
+}
+
 type Point [2]int
 
 func (p Point) X() int {
@@ -51,6 +95,13 @@ func (p Point) Y() int {
 	return p[1]
 }
 
+func (p Point) String() string {
+	return fmt.Sprintf("(%d,%d)", p[0], p[1])
+}
+
+// Path is a sequence of board points that spell a word.
+type Path []Point
+
 type Dict struct {
 	next map[rune]*Dict
 	ok   bool
@@ -0,0 +1,211 @@
+package boggle
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+const dawgFinal = 1 << 31
+
+// DAWG is a minimized directed acyclic word graph: an immutable set of
+// words in which common prefixes and suffixes are shared.
+//
+// Nodes are identified by their index, where the root is node 0. The
+// edges of node i are those from nodes[i] to nodes[i+1], ignoring the
+// final bit, which is set if node i ends a word. Edges are sorted by
+// label within each node.
+type DAWG struct {
+	nodes   []uint32
+	labels  []rune
+	targets []uint32
+	unmap   func() error
+}
+
+type dawgBuilder struct {
+	ids   map[string]uint32
+	nodes []dawgBuilderNode
+}
+
+type dawgBuilderNode struct {
+	ok      bool
+	labels  []rune
+	targets []uint32
+}
+
+// add registers the node u and returns its id, or false if no word
+// passes through u.
+func (b *dawgBuilder) add(u *Dict) (uint32, bool) {
+	if u == nil {
+		return 0, false
+	}
+	labels := make([]rune, 0, len(u.next))
+	for c := range u.next {
+		labels = append(labels, c)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i] < labels[j]
+	})
+	node := dawgBuilderNode{ok: u.ok}
+	for _, c := range labels {
+		if id, ok := b.add(u.next[c]); ok {
+			node.labels = append(node.labels, c)
+			node.targets = append(node.targets, id)
+		}
+	}
+	if !node.ok && len(node.labels) == 0 {
+		return 0, false
+	}
+
+	key := make([]byte, 1, 1+8*len(node.labels))
+	if node.ok {
+		key[0] = 1
+	}
+	for i := range node.labels {
+		var buf [8]byte
+		binary.LittleEndian.PutUint32(buf[:4], uint32(node.labels[i]))
+		binary.LittleEndian.PutUint32(buf[4:], node.targets[i])
+		key = append(key, buf[:]...)
+	}
+	if id, ok := b.ids[string(key)]; ok {
+		return id, true
+	}
+	id := uint32(len(b.nodes))
+	b.ids[string(key)] = id
+	b.nodes = append(b.nodes, node)
+	return id, true
+}
+
+// NewDAWG builds a DAWG of the words in d.
+func NewDAWG(d *Dict) *DAWG {
+	b := dawgBuilder{ids: make(map[string]uint32)}
+	if _, ok := b.add(d); !ok {
+		// Every DAWG has a root, even if it is empty.
+		b.nodes = append(b.nodes, dawgBuilderNode{})
+	}
+
+	// Nodes were added children first, so reverse them to put the root first.
+	n := uint32(len(b.nodes))
+	g := &DAWG{nodes: make([]uint32, 0, n+1)}
+	for i := range b.nodes {
+		node := b.nodes[len(b.nodes)-1-i]
+		first := uint32(len(g.labels))
+		if node.ok {
+			first |= dawgFinal
+		}
+		g.nodes = append(g.nodes, first)
+		g.labels = append(g.labels, node.labels...)
+		for _, id := range node.targets {
+			g.targets = append(g.targets, n-1-id)
+		}
+	}
+	g.nodes = append(g.nodes, uint32(len(g.labels)))
+	return g
+}
+
+func (g *DAWG) rootNode() uint32 {
+	return 0
+}
+
+func (g *DAWG) nextNode(n uint32, c rune) (uint32, bool) {
+	lo, hi := g.nodes[n]&^dawgFinal, g.nodes[n+1]&^dawgFinal
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		switch l := g.labels[mid]; {
+		case l == c:
+			return g.targets[mid], true
+		case l < c:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+func (g *DAWG) isFinal(n uint32) bool {
+	return g.nodes[n]&dawgFinal != 0
+}
+
+func (g *DAWG) eachNext(n uint32, f func(rune, uint32) bool) bool {
+	for i := g.nodes[n] &^ dawgFinal; i < g.nodes[n+1]&^dawgFinal; i++ {
+		if !f(g.labels[i], g.targets[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get returns the node reached by the letters of s, or false if no
+// word starts with s.
+func (g *DAWG) Get(s string) (uint32, bool) {
+	n := g.rootNode()
+	for _, c := range s {
+		var ok bool
+		if n, ok = g.nextNode(n, c); !ok {
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+func (g *DAWG) Contains(s string) bool {
+	n, ok := g.Get(s)
+	return ok && g.isFinal(n)
+}
+
+func (g *DAWG) HasPrefix(s string) bool {
+	_, ok := g.Get(s)
+	return ok
+}
+
+// Each calls f with each word in the DAWG, in order, until f returns an error.
+func (g *DAWG) Each(f func(string) error) error {
+	var (
+		word  []rune
+		visit func(n uint32) error
+	)
+	visit = func(n uint32) error {
+		if g.isFinal(n) {
+			if err := f(string(word)); err != nil {
+				return err
+			}
+		}
+		for i := g.nodes[n] &^ dawgFinal; i < g.nodes[n+1]&^dawgFinal; i++ {
+			word = append(word, g.labels[i])
+			if err := visit(g.targets[i]); err != nil {
+				return err
+			}
+			word = word[:len(word)-1]
+		}
+		return nil
+	}
+	return visit(g.rootNode())
+}
+
+func (g *DAWG) Len() int {
+	n := 0
+	_ = g.Each(func(_ string) error {
+		n++
+		return nil
+	})
+	return n
+}
+
+func (g *DAWG) Words() map[string]struct{} {
+	m := make(map[string]struct{})
+	_ = g.Each(func(s string) error {
+		m[s] = struct{}{}
+		return nil
+	})
+	return m
+}
+
+// Nodes returns the number of nodes in the DAWG.
+func (g *DAWG) Nodes() int {
+	return len(g.nodes) - 1
+}
+
+// Size returns the approximate number of bytes used by the DAWG.
+func (g *DAWG) Size() int {
+	return 4 * (len(g.nodes) + len(g.labels) + len(g.targets))
+}
@@ -0,0 +1,235 @@
+package boggle
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestDAWG(t *testing.T) {
+	words := []string{
+		"aloof", "barbaric", "crescendo", "deuterium", // No shared prefix or suffix.
+		"who", "what", "why", "where", "when", // Shared prefix.
+		"in", "wine", "swines", // Shared prefix and suffix.
+		"cats", "bats", "rats", // Shared suffix.
+		"ñandú", // Not ASCII.
+	}
+	dict := &Dict{}
+	for _, s := range words {
+		dict.Insert(s)
+	}
+	g := NewDAWG(dict)
+
+	assert.Equal(t, dict.Words(), g.Words())
+	assert.Equal(t, len(words), g.Len())
+	for _, s := range words {
+		assert.Truef(t, g.Contains(s), "not contains word %s", s)
+		assert.Truef(t, g.HasPrefix(string([]rune(s)[:1])), "not has prefix of word %s", s)
+	}
+	for _, s := range []string{"", "wh", "cat", "whom", "swine", "x"} {
+		assert.Falsef(t, g.Contains(s), "contains non-word %s", s)
+	}
+	assert.True(t, g.HasPrefix(""), "not has empty prefix")
+	assert.False(t, g.HasPrefix("x"), "has non-prefix")
+
+	// Each visits words in order.
+	var got []string
+	assert.NoError(t, g.Each(func(s string) error {
+		got = append(got, s)
+		return nil
+	}))
+	assert.IsIncreasing(t, got)
+
+	// Suffixes are shared.
+	suffixes := &Dict{}
+	for _, s := range []string{"cats", "bats", "rats"} {
+		suffixes.Insert(s)
+	}
+	assert.Equal(t, 5, NewDAWG(suffixes).Nodes(), "suffix not shared")
+
+	// Deleted words are not included.
+	dict.Delete("crescendo")
+	assert.Equal(t, dict.Words(), NewDAWG(dict).Words())
+
+	// Empty.
+	for _, d := range []*Dict{nil, {}} {
+		g := NewDAWG(d)
+		assert.Equal(t, 0, g.Len())
+		assert.False(t, g.Contains(""))
+		assert.True(t, g.HasPrefix(""))
+	}
+}
+
+// benchWords returns the words of the file named by BENCH_DICT, or
+// otherwise a list of pseudo-words with shared prefixes and suffixes.
+func benchWords(b *testing.B) []string {
+	if name := os.Getenv("BENCH_DICT"); name != "" {
+		d := &Dict{}
+		if err := d.ReadFile(name, DefaultNormalizer); err != nil {
+			b.Fatal(err)
+		}
+		words := make([]string, 0, d.Len())
+		for s := range d.Words() {
+			words = append(words, s)
+		}
+		return words
+	}
+	rng := rand.New(rand.NewSource(1))
+	const letters = "eeeeeeaaaaiiiiooootttnnnssrrhhlldducmfpgwybvkxjqz"
+	suffixes := []string{"", "s", "ed", "ing", "er", "ers", "ly", "ness"}
+	words := make([]string, 0, 100000)
+	for len(words) < cap(words) {
+		stem := make([]byte, 3+rng.Intn(6))
+		for i := range stem {
+			stem[i] = letters[rng.Intn(len(letters))]
+		}
+		for _, s := range suffixes[:1+rng.Intn(len(suffixes))] {
+			words = append(words, string(stem)+s)
+		}
+	}
+	return words
+}
+
+func benchBoards(n int) []Board {
+	rng := rand.New(rand.NewSource(1))
+	boards := make([]Board, n)
+	for i := range boards {
+		boards[i] = BigDice.Roll(rng)
+	}
+	return boards
+}
+
+func heapAlloc() uint64 {
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+func BenchmarkDict_Build(b *testing.B) {
+	words := benchWords(b)
+	b.ResetTimer()
+	var d *Dict
+	for i := 0; i < b.N; i++ {
+		d = &Dict{}
+		for _, s := range words {
+			d.Insert(s)
+		}
+	}
+	b.StopTimer()
+	d = nil
+	before := heapAlloc()
+	d = &Dict{}
+	for _, s := range words {
+		d.Insert(s)
+	}
+	b.ReportMetric(float64(int64(heapAlloc()-before)), "heap-bytes")
+	runtime.KeepAlive(d)
+	runtime.KeepAlive(words)
+}
+
+func BenchmarkDAWG_Build(b *testing.B) {
+	d := &Dict{}
+	for _, s := range benchWords(b) {
+		d.Insert(s)
+	}
+	b.ResetTimer()
+	var g *DAWG
+	for i := 0; i < b.N; i++ {
+		g = NewDAWG(d)
+	}
+	b.StopTimer()
+	g = nil
+	before := heapAlloc()
+	g = NewDAWG(d)
+	b.ReportMetric(float64(int64(heapAlloc()-before)), "heap-bytes")
+	b.ReportMetric(float64(g.Nodes()), "nodes")
+	runtime.KeepAlive(g)
+	runtime.KeepAlive(d)
+}
+
+func benchmarkContains(b *testing.B, lex Lexicon, words []string) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lex.Contains(words[i%len(words)])
+	}
+}
+
+func BenchmarkDict_Contains(b *testing.B) {
+	words := benchWords(b)
+	d := &Dict{}
+	for _, s := range words {
+		d.Insert(s)
+	}
+	benchmarkContains(b, d, words)
+}
+
+func BenchmarkDAWG_Contains(b *testing.B) {
+	words := benchWords(b)
+	d := &Dict{}
+	for _, s := range words {
+		d.Insert(s)
+	}
+	benchmarkContains(b, NewDAWG(d), words)
+}
+
+func benchmarkSolve(b *testing.B, lex Lexicon) {
+	boards := benchBoards(64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Solve(boards[i%len(boards)], lex)
+	}
+}
+
+func BenchmarkSolve_Dict(b *testing.B) {
+	d := &Dict{}
+	for _, s := range benchWords(b) {
+		d.Insert(s)
+	}
+	benchmarkSolve(b, d)
+}
+
+func BenchmarkSolve_DAWG(b *testing.B) {
+	d := &Dict{}
+	for _, s := range benchWords(b) {
+		d.Insert(s)
+	}
+	benchmarkSolve(b, NewDAWG(d))
+}
+
+// benchmarkSolver solves boards made by tiling big boards 3x3 to 15x15.
+func benchmarkSolver(b *testing.B, workers int) {
+	d := &Dict{}
+	for _, s := range benchWords(b) {
+		d.Insert(s)
+	}
+	g := NewDAWG(d)
+	boards := benchBoards(16)
+	for i, small := range boards {
+		large := make(Board, 15)
+		for y := range large {
+			for x := 0; x < 15; x++ {
+				large[y] = append(large[y], small[y%5][x%5])
+			}
+		}
+		boards[i] = large
+	}
+	s := Solver{Workers: workers}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Solve(context.Background(), boards[i%len(boards)], g); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSolver_Sequential(b *testing.B) {
+	benchmarkSolver(b, 1)
+}
+
+func BenchmarkSolver_Parallel(b *testing.B) {
+	benchmarkSolver(b, 0)
+}
@@ -0,0 +1,225 @@
+package boggle
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"unsafe"
+)
+
+// A DAWG file is a header followed by the node table, edge labels and
+// edge targets of the DAWG, each an array of little-endian uint32:
+//
+//	offset  size  field
+//	0       4     magic "DAWG"
+//	4       4     version
+//	8       4     number of entries in the node table
+//	12      4     number of edges
+//	16      4     CRC-32C checksum of everything after the header
+const (
+	dawgVersion    = 1
+	dawgHeaderSize = 20
+)
+
+var (
+	dawgMagic = [4]byte{'D', 'A', 'W', 'G'}
+	dawgCRC   = crc32.MakeTable(crc32.Castagnoli)
+)
+
+var ErrDAWGFormat = errors.New("invalid DAWG file")
+
+var nativeLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+type dawgHeader struct {
+	nodes    int
+	edges    int
+	checksum uint32
+}
+
+func (h dawgHeader) payloadSize() int {
+	return 4 * (h.nodes + 2*h.edges)
+}
+
+func parseDAWGHeader(b []byte) (dawgHeader, error) {
+	if len(b) < dawgHeaderSize {
+		return dawgHeader{}, fmt.Errorf("%w: header too short", ErrDAWGFormat)
+	}
+	if [4]byte{b[0], b[1], b[2], b[3]} != dawgMagic {
+		return dawgHeader{}, fmt.Errorf("%w: bad magic", ErrDAWGFormat)
+	}
+	le := binary.LittleEndian
+	if version := le.Uint32(b[4:]); version != dawgVersion {
+		return dawgHeader{}, fmt.Errorf("%w: unsupported version %d", ErrDAWGFormat, version)
+	}
+	h := dawgHeader{
+		nodes:    int(le.Uint32(b[8:])),
+		edges:    int(le.Uint32(b[12:])),
+		checksum: le.Uint32(b[16:]),
+	}
+	if h.nodes < 2 || h.nodes > dawgFinal || h.edges >= dawgFinal {
+		return dawgHeader{}, fmt.Errorf("%w: bad size", ErrDAWGFormat)
+	}
+	return h, nil
+}
+
+// decodeDAWG decodes the payload of a DAWG file. If share is true and
+// the host is little-endian then the DAWG refers to b instead of a copy.
+func decodeDAWG(h dawgHeader, b []byte, share bool) (*DAWG, error) {
+	if len(b) != h.payloadSize() {
+		return nil, fmt.Errorf("%w: payload is %d bytes, want %d", ErrDAWGFormat, len(b), h.payloadSize())
+	}
+	if crc32.Checksum(b, dawgCRC) != h.checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrDAWGFormat)
+	}
+	var g DAWG
+	if share && nativeLittleEndian {
+		p := unsafe.Pointer(&b[0])
+		g.nodes = unsafe.Slice((*uint32)(p), h.nodes)
+		if h.edges > 0 {
+			g.labels = unsafe.Slice((*rune)(unsafe.Add(p, 4*h.nodes)), h.edges)
+			g.targets = unsafe.Slice((*uint32)(unsafe.Add(p, 4*(h.nodes+h.edges))), h.edges)
+		}
+	} else {
+		le := binary.LittleEndian
+		g.nodes = make([]uint32, h.nodes)
+		for i := range g.nodes {
+			g.nodes[i] = le.Uint32(b[4*i:])
+		}
+		b = b[4*h.nodes:]
+		g.labels = make([]rune, h.edges)
+		for i := range g.labels {
+			g.labels[i] = rune(le.Uint32(b[4*i:]))
+		}
+		b = b[4*h.edges:]
+		g.targets = make([]uint32, h.edges)
+		for i := range g.targets {
+			g.targets[i] = le.Uint32(b[4*i:])
+		}
+	}
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// validate checks that every edge is in range and leads to a later
+// node, so that the graph can be walked safely and is acyclic.
+func (g *DAWG) validate() error {
+	n := uint32(len(g.nodes) - 1)
+	if g.nodes[n] != uint32(len(g.labels)) || len(g.labels) != len(g.targets) {
+		return fmt.Errorf("%w: bad edge count", ErrDAWGFormat)
+	}
+	for i := uint32(0); i < n; i++ {
+		first, end := g.nodes[i]&^dawgFinal, g.nodes[i+1]&^dawgFinal
+		if first > end {
+			return fmt.Errorf("%w: bad edges of node %d", ErrDAWGFormat, i)
+		}
+		for e := first; e < end; e++ {
+			if t := g.targets[e]; t <= i || t >= n {
+				return fmt.Errorf("%w: bad target of edge %d", ErrDAWGFormat, e)
+			}
+			if e > first && g.labels[e] <= g.labels[e-1] {
+				return fmt.Errorf("%w: unsorted edges of node %d", ErrDAWGFormat, i)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTo writes the DAWG to w in the DAWG file format.
+func (g *DAWG) WriteTo(w io.Writer) (int64, error) {
+	h := dawgHeader{nodes: len(g.nodes), edges: len(g.labels)}
+	b := make([]byte, dawgHeaderSize+h.payloadSize())
+	le := binary.LittleEndian
+	copy(b, dawgMagic[:])
+	le.PutUint32(b[4:], dawgVersion)
+	le.PutUint32(b[8:], uint32(h.nodes))
+	le.PutUint32(b[12:], uint32(h.edges))
+	p := b[dawgHeaderSize:]
+	for _, v := range g.nodes {
+		le.PutUint32(p, v)
+		p = p[4:]
+	}
+	for _, c := range g.labels {
+		le.PutUint32(p, uint32(c))
+		p = p[4:]
+	}
+	for _, v := range g.targets {
+		le.PutUint32(p, v)
+		p = p[4:]
+	}
+	le.PutUint32(b[16:], crc32.Checksum(b[dawgHeaderSize:], dawgCRC))
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom replaces the DAWG with one read from r in the DAWG file format.
+func (g *DAWG) ReadFrom(r io.Reader) (int64, error) {
+	b := make([]byte, dawgHeaderSize)
+	n, err := io.ReadFull(r, b)
+	if err != nil {
+		return int64(n), fmt.Errorf("%w: %v", ErrDAWGFormat, err)
+	}
+	h, err := parseDAWGHeader(b)
+	if err != nil {
+		return int64(n), err
+	}
+	// The header is not trusted with the size of the buffer, which grows
+	// as the payload is read instead.
+	b, err = io.ReadAll(io.LimitReader(r, int64(h.payloadSize())))
+	m := len(b)
+	if err != nil {
+		return int64(n + m), fmt.Errorf("%w: %v", ErrDAWGFormat, err)
+	}
+	if m < h.payloadSize() {
+		return int64(n + m), fmt.Errorf("%w: %v", ErrDAWGFormat, io.ErrUnexpectedEOF)
+	}
+	d, err := decodeDAWG(h, b, false)
+	if err != nil {
+		return int64(n + m), err
+	}
+	*g = *d
+	return int64(n + m), nil
+}
+
+// OpenDAWG opens the named DAWG file. Where possible the file is mapped
+// into memory rather than read, so that it opens in constant time and
+// its pages are shared between processes; Close releases the mapping.
+func OpenDAWG(name string) (*DAWG, error) {
+	b, unmap, err := mapFile(name)
+	if err != nil {
+		return nil, err
+	}
+	h, err := parseDAWGHeader(b)
+	if err != nil {
+		_ = unmap()
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	g, err := decodeDAWG(h, b[dawgHeaderSize:], true)
+	if err != nil {
+		_ = unmap()
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if nativeLittleEndian {
+		g.unmap = unmap
+	} else if err := unmap(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Close releases the file mapping of a DAWG opened by OpenDAWG. The
+// DAWG must not be used after it is closed.
+func (g *DAWG) Close() error {
+	if g.unmap == nil {
+		return nil
+	}
+	unmap := g.unmap
+	*g = DAWG{}
+	return unmap()
+}
@@ -0,0 +1,141 @@
+package boggle
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testDAWG() *DAWG {
+	dict := &Dict{}
+	for _, s := range []string{"who", "what", "why", "cats", "bats", "ñandú"} {
+		dict.Insert(s)
+	}
+	return NewDAWG(dict)
+}
+
+func TestDAWG_WriteTo(t *testing.T) {
+	for name, g := range map[string]*DAWG{
+		"Words": testDAWG(),
+		"Empty": NewDAWG(nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := g.WriteTo(&buf)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(buf.Len()), n)
+
+			var got DAWG
+			m, err := got.ReadFrom(bytes.NewReader(buf.Bytes()))
+			assert.NoError(t, err)
+			assert.Equal(t, n, m)
+			assert.Equal(t, g.Words(), got.Words())
+			assert.Equal(t, g.Nodes(), got.Nodes())
+		})
+	}
+}
+
+func TestDAWG_ReadFrom_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := testDAWG().WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+	corrupt := func(f func(b []byte) []byte) []byte {
+		b := make([]byte, len(valid))
+		copy(b, valid)
+		return f(b)
+	}
+
+	tests := map[string][]byte{
+		"Empty":     {},
+		"Magic":     corrupt(func(b []byte) []byte { b[0] = 'X'; return b }),
+		"Version":   corrupt(func(b []byte) []byte { b[4] = 2; return b }),
+		"Truncated": valid[:len(valid)-1],
+		"Checksum":  corrupt(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }),
+		"Oversize": corrupt(func(b []byte) []byte {
+			// Claims a payload of tens of gigabytes that is not there.
+			copy(b[8:], []byte{0xff, 0xff, 0xff, 0x7f, 0xff, 0xff, 0xff, 0x7f})
+			return b[:dawgHeaderSize]
+		}),
+	}
+	for name, b := range tests {
+		t.Run(name, func(t *testing.T) {
+			var g DAWG
+			_, err := g.ReadFrom(bytes.NewReader(b))
+			assert.ErrorIs(t, err, ErrDAWGFormat)
+		})
+	}
+}
+
+func TestDAWG_Validate(t *testing.T) {
+	tests := map[string]*DAWG{
+		"EdgeCount":  {nodes: []uint32{0, 0}, labels: []rune{'a'}, targets: []uint32{1}},
+		"Cycle":      {nodes: []uint32{0, 1, 1}, labels: []rune{'a'}, targets: []uint32{0}},
+		"OutOfRange": {nodes: []uint32{0, 1, 1}, labels: []rune{'a'}, targets: []uint32{2}},
+		"Unsorted":   {nodes: []uint32{0, 2 | dawgFinal, 2}, labels: []rune{'b', 'a'}, targets: []uint32{1, 1}},
+	}
+	for name, g := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, g.validate(), ErrDAWGFormat)
+		})
+	}
+	assert.NoError(t, testDAWG().validate())
+}
+
+func TestOpenDAWG(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "test.dawg")
+	want := testDAWG()
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := want.WriteTo(f); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := OpenDAWG(name)
+	if err != nil {
+		t.Fatalf("open error: %v", err)
+	}
+	assert.Equal(t, want.Words(), g.Words())
+	assert.Equal(t, Solve(Board{{"w", "h", "o"}}, want), Solve(Board{{"w", "h", "o"}}, g))
+	assert.NoError(t, g.Close())
+
+	if err := os.WriteFile(name, []byte("not a dawg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = OpenDAWG(name)
+	assert.ErrorIs(t, err, ErrDAWGFormat)
+}
+
+func BenchmarkOpenDAWG(b *testing.B) {
+	d := &Dict{}
+	for _, s := range benchWords(b) {
+		d.Insert(s)
+	}
+	name := filepath.Join(b.TempDir(), "bench.dawg")
+	f, err := os.Create(name)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := NewDAWG(d).WriteTo(f); err != nil {
+		b.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g, err := OpenDAWG(name)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = g.Close()
+	}
+}
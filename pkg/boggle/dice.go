@@ -0,0 +1,105 @@
+package boggle
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Die lists the tile on each face of a die.
+type Die []Tile
+
+// MustParseDice parses each die from a row of tiles, as by ParseRow.
+// It panics if a die cannot be parsed.
+func MustParseDice(rows ...string) []Die {
+	dice := make([]Die, len(rows))
+	for i, s := range rows {
+		row, err := ParseRow(s)
+		if err != nil {
+			panic(fmt.Errorf("invalid die %d: %w", i, err))
+		}
+		dice[i] = row
+	}
+	return dice
+}
+
+// DiceSet is a set of dice that are rolled to fill a board.
+type DiceSet struct {
+	Size [2]int
+	Dice []Die
+}
+
+// Roll shuffles the dice into a board and rolls each of them.
+//
+// The same board is rolled from the same sequence of random numbers
+// so a board can be reproduced from the seed of the source.
+func (s DiceSet) Roll(rng *rand.Rand) Board {
+	dice := make([]Die, len(s.Dice))
+	copy(dice, s.Dice)
+	rng.Shuffle(len(dice), func(i, j int) {
+		dice[i], dice[j] = dice[j], dice[i]
+	})
+	board := make(Board, s.Size[Y])
+	for y := range board {
+		board[y] = make([]Tile, s.Size[X])
+		for x := range board[y] {
+			faces := dice[y*s.Size[X]+x]
+			board[y][x] = faces[rng.Intn(len(faces))]
+		}
+	}
+	return board
+}
+
+var (
+	// ClassicDice are the dice of 4x4 Boggle from 1987.
+	ClassicDice = DiceSet{
+		Size: [2]int{4, 4},
+		Dice: MustParseDice(
+			"aaciot", "abilty", "abjmo[qu]", "acdemp",
+			"acelrs", "adenvz", "ahmors", "biforx",
+			"denosw", "dknotu", "eefhiy", "egkluy",
+			"egintv", "ehinps", "elpstu", "gilruw",
+		),
+	}
+	// NewDice are the dice of 4x4 Boggle in current editions.
+	NewDice = DiceSet{
+		Size: [2]int{4, 4},
+		Dice: MustParseDice(
+			"aaeegn", "abbjoo", "achops", "affkps",
+			"aoottw", "cimotu", "deilrx", "delrvy",
+			"distty", "eeghnw", "eeinsu", "ehrtvw",
+			"eiosst", "elrtty", "himnu[qu]", "hlnnrz",
+		),
+	}
+	// BigDice are the dice of 5x5 Big Boggle.
+	BigDice = DiceSet{
+		Size: [2]int{5, 5},
+		Dice: MustParseDice(
+			"aaafrs", "aaeeee", "aafirs", "adennn", "aeeeem",
+			"aeegmu", "aegmnn", "afirsy", "bjk[qu]xz", "ccenst",
+			"ceiilt", "ceilpt", "ceipst", "ddhnot", "dhhlor",
+			"dhlnor", "dhlnor", "eiiitt", "emottt", "ensssu",
+			"fiprsy", "gorrvw", "iprrry", "nootuw", "ooottu",
+		),
+	}
+	// SuperBigDice are the dice of 6x6 Super Big Boggle, which include
+	// a die of two-letter tiles and a die with blank faces. A blank face
+	// is a blocked tile, which no word may use.
+	SuperBigDice = DiceSet{
+		Size: [2]int{6, 6},
+		Dice: MustParseDice(
+			"aaafrs", "aaeeee", "aaeeoo", "aafirs", "abdeio", "adennn",
+			"aeeeem", "aeegmu", "aegmnn", "aeilmn", "aeinou", "afirsy",
+			"[an][er][he][in][qu][th]", "bbjkxz", "ccenst", "cddlnn", "ceiitt", "ceipst",
+			"cfgnuy", "ddhnot", "dhhlor", "dhhnow", "dhlnor", "ehilrs",
+			"eiilst", "eilpst", "eio###", "emttto", "ensssu", "gorrvw",
+			"hirstv", "hoprst", "iprsyy", "jk[qu]wxz", "nootuw", "ooottu",
+		),
+	}
+)
+
+var DiceSets = map[string]DiceSet{
+	"classic":  ClassicDice,
+	"new":      NewDice,
+	"big":      BigDice,
+	"superbig": SuperBigDice,
+}
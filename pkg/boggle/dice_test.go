@@ -0,0 +1,47 @@
+package boggle
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestDiceSet(t *testing.T) {
+	for name, set := range DiceSets {
+		t.Run(name, func(t *testing.T) {
+			assert.Len(t, set.Dice, set.Size[X]*set.Size[Y], "unexpected number of dice")
+
+			board := set.Roll(rand.New(rand.NewSource(42)))
+			assert.True(t, board.IsRect(), "board is not rectangular")
+			assert.Equal(t, set.Size, board.Size(), "unexpected board size")
+
+			// Every tile is a face of one of the dice.
+			faces := make(map[Tile]bool)
+			for _, d := range set.Dice {
+				for _, c := range d {
+					faces[c] = true
+				}
+			}
+			for _, p := range board.Points() {
+				assert.Truef(t, faces[board.At(p)], "tile %s at %v is not a face of any die", board.At(p), p)
+			}
+
+			// Same seed rolls the same board.
+			assert.Equal(t, board, set.Roll(rand.New(rand.NewSource(42))), "unexpected board from same seed")
+			assert.NotEqual(t, board, set.Roll(rand.New(rand.NewSource(43))), "unexpected board from different seed")
+		})
+	}
+}
+
+func TestSuperBigDice(t *testing.T) {
+	var blocked int
+	for _, d := range SuperBigDice.Dice {
+		for _, face := range d {
+			assert.False(t, face.IsWildcard(), "blank face is a wildcard")
+			if face.IsBlocked() {
+				blocked++
+			}
+		}
+	}
+	assert.Equal(t, 3, blocked, "unexpected number of blank faces")
+}
@@ -0,0 +1,96 @@
+package boggle
+
+// Lexicon is a set of words, such as a *Dict or a *DAWG.
+type Lexicon interface {
+	Contains(s string) bool
+	HasPrefix(s string) bool
+	Len() int
+	Words() map[string]struct{}
+}
+
+// walker walks a set of words a letter at a time from the root, where
+// N identifies a node.
+type walker[N any] interface {
+	rootNode() N
+	nextNode(n N, c rune) (N, bool)
+	isFinal(n N) bool
+	// eachNext calls f with each letter that follows n and the node it
+	// leads to, until f returns false, and returns false if f did.
+	eachNext(n N, f func(c rune, m N) bool) bool
+}
+
+func (d *Dict) HasPrefix(s string) bool {
+	return d.Get(s) != nil
+}
+
+func (d *Dict) rootNode() *Dict {
+	return d
+}
+
+func (d *Dict) nextNode(u *Dict, c rune) (*Dict, bool) {
+	if u == nil {
+		return nil, false
+	}
+	v := u.next[c]
+	return v, v != nil
+}
+
+func (d *Dict) isFinal(u *Dict) bool {
+	return u.ok
+}
+
+func (d *Dict) eachNext(u *Dict, f func(rune, *Dict) bool) bool {
+	if u == nil {
+		return true
+	}
+	for c, v := range u.next {
+		if !f(c, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// prefixWalker walks any Lexicon by the prefix spelled so far, where
+// the letters that may follow a prefix are those of the alphabet.
+type prefixWalker struct {
+	lex      Lexicon
+	alphabet []rune
+}
+
+// alphabetOf returns the letters of the words in lex.
+func alphabetOf(lex Lexicon) []rune {
+	seen := make(map[rune]bool)
+	var alphabet []rune
+	for s := range lex.Words() {
+		for _, c := range s {
+			if !seen[c] {
+				seen[c] = true
+				alphabet = append(alphabet, c)
+			}
+		}
+	}
+	return alphabet
+}
+
+func (w prefixWalker) rootNode() string {
+	return ""
+}
+
+func (w prefixWalker) nextNode(s string, c rune) (string, bool) {
+	s += string(c)
+	return s, w.lex.HasPrefix(s)
+}
+
+func (w prefixWalker) isFinal(s string) bool {
+	return w.lex.Contains(s)
+}
+
+func (w prefixWalker) eachNext(s string, f func(rune, string) bool) bool {
+	for _, c := range w.alphabet {
+		if t, ok := w.nextNode(s, c); ok && !f(c, t) {
+			return false
+		}
+	}
+	return true
+}
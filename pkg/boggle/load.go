@@ -0,0 +1,158 @@
+package boggle
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizer normalizes words as they are loaded into a dictionary.
+type Normalizer struct {
+	Lower        bool // Convert to lower case.
+	NFC          bool // Compose to Unicode normalization form C.
+	StripAccents bool // Remove combining marks, e.g. "é" becomes "e".
+	LettersOnly  bool // Reject words containing anything but letters.
+	MinLength    int  // Reject words with fewer letters.
+}
+
+// DefaultNormalizer accepts lower case words of letters that are long
+// enough to score under the classic rules.
+var DefaultNormalizer = Normalizer{
+	Lower:       true,
+	NFC:         true,
+	LettersOnly: true,
+	MinLength:   MinLength,
+}
+
+// Normalize returns the normalized word, or false if the word is rejected.
+func (n Normalizer) Normalize(s string) (string, bool) {
+	if n.NFC {
+		s = norm.NFC.String(s)
+	}
+	if n.Lower {
+		s = strings.ToLower(s)
+	}
+	if n.StripAccents {
+		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		var err error
+		if s, _, err = transform.String(t, s); err != nil {
+			return "", false
+		}
+	}
+	if n.LettersOnly {
+		for _, c := range s {
+			if !unicode.IsLetter(c) {
+				return "", false
+			}
+		}
+	}
+	if len([]rune(s)) < n.MinLength || s == "" {
+		return "", false
+	}
+	return s, true
+}
+
+func (d *Dict) readLines(r io.Reader, n Normalizer, word func(line string) string) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		s := word(strings.TrimSpace(scanner.Text()))
+		if s == "" {
+			continue
+		}
+		if s, ok := n.Normalize(s); ok {
+			d.Insert(s)
+		}
+	}
+	return scanner.Err()
+}
+
+// ReadList inserts the words of a newline-separated word list.
+func (d *Dict) ReadList(r io.Reader, n Normalizer) error {
+	return d.readLines(r, n, func(line string) string {
+		return line
+	})
+}
+
+// ReadHunspell inserts the words of a Hunspell .dic file.
+//
+// Only the stem of each entry is inserted: affix flags are discarded
+// rather than expanded using a .aff file.
+func (d *Dict) ReadHunspell(r io.Reader, n Normalizer) error {
+	first := true
+	return d.readLines(r, n, func(line string) string {
+		if first {
+			// The first line is the approximate number of entries.
+			first = false
+			if _, err := strconv.Atoi(line); err == nil {
+				return ""
+			}
+		}
+		if strings.HasPrefix(line, "#") {
+			return ""
+		}
+		// Entries are followed by optional morphological fields.
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			line = line[:i]
+		}
+		// Flags follow an unescaped slash.
+		var b strings.Builder
+		for i := 0; i < len(line); i++ {
+			if line[i] == '\\' && i+1 < len(line) && line[i+1] == '/' {
+				b.WriteByte('/')
+				i++
+				continue
+			}
+			if line[i] == '/' {
+				break
+			}
+			b.WriteByte(line[i])
+		}
+		return b.String()
+	})
+}
+
+// ReadGzip inserts the words of a gzip-compressed word list, read by
+// the given function, e.g. (*Dict).ReadList.
+func (d *Dict) ReadGzip(r io.Reader, n Normalizer, read func(*Dict, io.Reader, Normalizer) error) error {
+	z, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer z.Close()
+	return read(d, z, n)
+}
+
+// ReadFile inserts the words of a word list file. Files ending .dic
+// are read as Hunspell files, and files ending .gz are decompressed,
+// e.g. "en_US.dic.gz". Any other file is read as a newline-separated list.
+func (d *Dict) ReadFile(name string, n Normalizer) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	read := (*Dict).ReadList
+	base := strings.TrimSuffix(name, ".gz")
+	if strings.HasSuffix(base, ".dic") {
+		read = (*Dict).ReadHunspell
+	}
+	if base != name {
+		err = d.ReadGzip(f, n, read)
+	} else {
+		err = read(d, f, n)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", name, err)
+	}
+	return nil
+}
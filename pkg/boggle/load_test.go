@@ -0,0 +1,94 @@
+package boggle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizer(t *testing.T) {
+	tests := []struct {
+		name   string
+		norm   Normalizer
+		s      string
+		want   string
+		wantOK bool
+	}{
+		{"zero", Normalizer{}, "Café-au-lait", "Café-au-lait", true},
+		{"zero empty", Normalizer{}, "", "", false},
+		{"lower", Normalizer{Lower: true}, "CAT", "cat", true},
+		{"nfc", Normalizer{NFC: true}, "cafe\u0301", "caf\u00e9", true},
+		{"strip accents", Normalizer{StripAccents: true}, "crème brûlée", "creme brulee", true},
+		{"strip accents decomposed", Normalizer{StripAccents: true}, "cafe\u0301", "cafe", true},
+		{"letters only", Normalizer{LettersOnly: true}, "don't", "", false},
+		{"letters only unicode", Normalizer{LettersOnly: true}, "ñandú", "ñandú", true},
+		{"min length", Normalizer{MinLength: 3}, "at", "", false},
+		{"min length letters", Normalizer{NFC: true, MinLength: 3}, "été", "été", true},
+		{"default", DefaultNormalizer, "Zebra", "zebra", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := test.norm.Normalize(test.s)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestDict_ReadList(t *testing.T) {
+	d := &Dict{}
+	err := d.ReadList(strings.NewReader("Aardvark\r\n\n  badger \ncat's\nox\n"), DefaultNormalizer)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"aardvark": {}, "badger": {}}, d.Words())
+}
+
+func TestDict_ReadHunspell(t *testing.T) {
+	d := &Dict{}
+	err := d.ReadHunspell(strings.NewReader("4\nhello/MS\nworld\tpo:noun\nand\\/or/X\n# comment\nZürich/M\n"), Normalizer{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]struct{}{"hello": {}, "world": {}, "and/or": {}, "Zürich": {}}, d.Words())
+}
+
+func TestDict_ReadFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, b []byte) string {
+		name = filepath.Join(dir, name)
+		if err := os.WriteFile(name, b, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return name
+	}
+	gz := func(s string) []byte {
+		var b bytes.Buffer
+		z := gzip.NewWriter(&b)
+		_, _ = z.Write([]byte(s))
+		_ = z.Close()
+		return b.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want map[string]struct{}
+	}{
+		{"list", write("words.txt", []byte("cat\ndog\n")), map[string]struct{}{"cat": {}, "dog": {}}},
+		{"gzip list", write("words.txt.gz", gz("cat\ndog\n")), map[string]struct{}{"cat": {}, "dog": {}}},
+		{"hunspell", write("en.dic", []byte("2\ncat/S\ndog/S\n")), map[string]struct{}{"cat": {}, "dog": {}}},
+		{"gzip hunspell", write("en.dic.gz", gz("2\ncat/S\ndog/S\n")), map[string]struct{}{"cat": {}, "dog": {}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := &Dict{}
+			assert.NoError(t, d.ReadFile(test.file, DefaultNormalizer))
+			assert.Equal(t, test.want, d.Words())
+		})
+	}
+
+	d := &Dict{}
+	assert.Error(t, d.ReadFile(filepath.Join(dir, "missing.txt"), DefaultNormalizer))
+	assert.Error(t, d.ReadFile(write("bad.txt.gz", []byte("cat\n")), DefaultNormalizer))
+}
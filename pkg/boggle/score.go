@@ -0,0 +1,91 @@
+package boggle
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Scorer scores the words found in a game.
+type Scorer interface {
+	Score(word string) int
+}
+
+type ScorerFunc func(word string) int
+
+func (f ScorerFunc) Score(word string) int {
+	return f(word)
+}
+
+// Table scores a word by its length in letters, where the value at
+// index n is the score for a word of n letters. Words longer than
+// the table score the same as the last entry.
+type Table []int
+
+func (t Table) Score(word string) int {
+	if len(t) == 0 {
+		return 0
+	}
+	n := len([]rune(word))
+	if n >= len(t) {
+		return t[len(t)-1]
+	}
+	return t[n]
+}
+
+var (
+	// ClassicTable is the scoring table for 4x4 Boggle.
+	ClassicTable = Table{0, 0, 0, 1, 1, 2, 3, 5, 11}
+	// BigTable is the scoring table for 5x5 Big Boggle.
+	BigTable = Table{0, 0, 0, 0, 1, 2, 3, 5, 11}
+)
+
+var Tables = map[string]Table{
+	"classic": ClassicTable,
+	"big":     BigTable,
+}
+
+// ParseTable returns the named table from Tables, or otherwise parses
+// a custom table from a comma-separated list of scores, e.g. "0,0,0,1,1,2".
+func ParseTable(s string) (Table, error) {
+	if t, ok := Tables[s]; ok {
+		return t, nil
+	}
+	fields := strings.Split(s, ",")
+	t := make(Table, len(fields))
+	for i, field := range fields {
+		var err error
+		if t[i], err = strconv.Atoi(strings.TrimSpace(field)); err != nil {
+			return nil, fmt.Errorf("invalid score table '%s': %w", s, err)
+		}
+	}
+	return t, nil
+}
+
+// Scores totals the score of the words found by each player, where
+// words found by more than one player score zero. A word found by the
+// same player more than once is only scored once.
+func Scores[P comparable](scorer Scorer, found map[P][]string) map[P]int {
+	players := make(map[string]map[P]struct{})
+	for p, words := range found {
+		for _, s := range words {
+			if players[s] == nil {
+				players[s] = make(map[P]struct{})
+			}
+			players[s][p] = struct{}{}
+		}
+	}
+	scores := make(map[P]int, len(found))
+	for p := range found {
+		scores[p] = 0
+	}
+	for s, ps := range players {
+		if len(ps) != 1 {
+			continue
+		}
+		for p := range ps {
+			scores[p] += scorer.Score(s)
+		}
+	}
+	return scores
+}
@@ -0,0 +1,58 @@
+package boggle
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Table
+		want  map[string]int
+	}{
+		{"classic", ClassicTable, map[string]int{
+			"": 0, "at": 0, "cat": 1, "cats": 1, "carts": 2, "carton": 3, "cartons": 5, "cartoons": 11, "cartoonist": 11,
+		}},
+		{"big", BigTable, map[string]int{
+			"cat": 0, "cats": 1, "carts": 2, "carton": 3, "cartons": 5, "cartoons": 11, "cartoonist": 11,
+		}},
+		{"empty", Table{}, map[string]int{
+			"cat": 0,
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for s, want := range test.want {
+				assert.Equalf(t, want, test.table.Score(s), "unexpected score for %s", s)
+			}
+		})
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	table, err := ParseTable("big")
+	assert.NoError(t, err)
+	assert.Equal(t, BigTable, table)
+
+	table, err = ParseTable("0, 1, 2")
+	assert.NoError(t, err)
+	assert.Equal(t, Table{0, 1, 2}, table)
+
+	_, err = ParseTable("bogus")
+	assert.Error(t, err)
+}
+
+func TestScores(t *testing.T) {
+	found := map[string][]string{
+		"alice": {"cat", "cart", "cartons", "cart"}, // Found cart twice.
+		"bob":   {"cat", "rat"},                     // Found cat as well.
+		"carol": {},
+	}
+	want := map[string]int{
+		"alice": 1 + 5,
+		"bob":   1,
+		"carol": 0,
+	}
+	assert.Equal(t, want, Scores(ClassicTable, found))
+}
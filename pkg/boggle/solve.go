@@ -0,0 +1,190 @@
+package boggle
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// Solution is a word found on a board along with every path that spells it.
+type Solution struct {
+	Word  string
+	Paths []Path
+}
+
+// Solve finds every word in lex that can be spelled on the board by
+// a path of adjacent tiles, using each tile at most once and never a
+// blocked tile. Tiles of more than one letter contribute all of their
+// letters to a word, and wildcard tiles contribute any one letter, so
+// the word of a solution gives the letters its wildcards stand for.
+//
+// Solutions are ordered by word and paths are ordered by the position
+// of their starting tile, then by the order in which they were found.
+func Solve(board Board, lex Lexicon) []Solution {
+	solutions, _ := Solver{Workers: 1}.Solve(context.Background(), board, lex)
+	return solutions
+}
+
+// Solver solves boards concurrently, searching from each starting tile
+// on a pool of workers. The solutions are the same as those of Solve.
+type Solver struct {
+	// Workers is the number of goroutines to search with, or if zero
+	// then GOMAXPROCS.
+	Workers int
+	// Topology decides which tiles are adjacent, or if nil then Square.
+	Topology Topology
+}
+
+// Solve solves the board as Solve does, or returns the context error if
+// the context is done before the search is complete.
+func (s Solver) Solve(ctx context.Context, board Board, lex Lexicon) ([]Solution, error) {
+	switch lex := lex.(type) {
+	case nil:
+		return nil, nil
+	case *Dict:
+		if lex == nil {
+			return nil, nil
+		}
+		return solve[*Dict](ctx, s, board, lex)
+	case *DAWG:
+		return solve[uint32](ctx, s, board, lex)
+	default:
+		w := prefixWalker{lex: lex}
+		if board.hasWildcard() {
+			w.alphabet = alphabetOf(lex)
+		}
+		return solve[string](ctx, s, board, w)
+	}
+}
+
+// checkInterval is the number of tiles visited between checks for
+// cancellation.
+const checkInterval = 1024
+
+type search[N any] struct {
+	board    Board
+	topology Topology
+	w        walker[N]
+	done     <-chan struct{}
+	found    map[string][]Path
+	seen     map[Point]bool
+	path     []Point
+	word     []byte
+	steps    int
+}
+
+// visit searches from tile p at node u, returning false if the search
+// was cancelled.
+func (s *search[N]) visit(p Point, u N) bool {
+	if s.steps++; s.steps%checkInterval == 0 {
+		select {
+		case <-s.done:
+			return false
+		default:
+		}
+	}
+	t := s.board.At(p)
+	switch {
+	case t.IsBlocked():
+		return true
+	case t.IsWildcard():
+		return s.w.eachNext(u, func(c rune, v N) bool {
+			return s.enter(p, string(c), v)
+		})
+	}
+	for _, c := range t {
+		var ok bool
+		if u, ok = s.w.nextNode(u, c); !ok {
+			return true
+		}
+	}
+	return s.enter(p, string(t), u)
+}
+
+// enter extends the path to tile p, which adds letters to the word and
+// reaches node u, and searches on from its neighbours.
+func (s *search[N]) enter(p Point, letters string, u N) bool {
+	n := len(s.word)
+	s.path = append(s.path, p)
+	s.word = append(s.word, letters...)
+	s.seen[p] = true
+	if s.w.isFinal(u) {
+		w := string(s.word)
+		s.found[w] = append(s.found[w], append(Path(nil), s.path...))
+	}
+	ok := true
+	for _, q := range s.topology.Neighbours(s.board, p) {
+		if !s.seen[q] {
+			if ok = s.visit(q, u); !ok {
+				break
+			}
+		}
+	}
+	s.seen[p] = false
+	s.path = s.path[:len(s.path)-1]
+	s.word = s.word[:n]
+	return ok
+}
+
+func solve[N any](ctx context.Context, solver Solver, board Board, w walker[N]) ([]Solution, error) {
+	starts := board.Points()
+	topology := solver.Topology
+	if topology == nil {
+		topology = Square
+	}
+	workers := solver.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(starts) {
+		workers = len(starts)
+	}
+
+	// Each start has its own results so that they can be merged in order.
+	found := make([]map[string][]Path, len(starts))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := search[N]{board: board, topology: topology, w: w, done: ctx.Done(), seen: make(map[Point]bool)}
+			for j := range jobs {
+				s.found = make(map[string][]Path)
+				if !s.visit(starts[j], w.rootNode()) {
+					return
+				}
+				found[j] = s.found
+			}
+		}()
+	}
+feed:
+	for j := range starts {
+		select {
+		case jobs <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	merged := make(map[string][]Path)
+	for _, m := range found {
+		for s, paths := range m {
+			merged[s] = append(merged[s], paths...)
+		}
+	}
+	solutions := make([]Solution, 0, len(merged))
+	for s, paths := range merged {
+		solutions = append(solutions, Solution{s, paths})
+	}
+	sort.Slice(solutions, func(i, j int) bool {
+		return solutions[i].Word < solutions[j].Word
+	})
+	return solutions, nil
+}
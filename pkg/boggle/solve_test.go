@@ -0,0 +1,218 @@
+package boggle
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// wordSet is a Lexicon that is not known to the solver.
+type wordSet map[string]struct{}
+
+func (w wordSet) Contains(s string) bool {
+	_, ok := w[s]
+	return ok
+}
+
+func (w wordSet) HasPrefix(s string) bool {
+	for t := range w {
+		if strings.HasPrefix(t, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w wordSet) Len() int {
+	return len(w)
+}
+
+func (w wordSet) Words() map[string]struct{} {
+	return w
+}
+
+// cancelAfter is a Lexicon that calls cancel after n prefix lookups.
+type cancelAfter struct {
+	wordSet
+	n      int
+	cancel func()
+}
+
+func (c *cancelAfter) HasPrefix(s string) bool {
+	if c.n--; c.n == 0 {
+		c.cancel()
+	}
+	return c.wordSet.HasPrefix(s)
+}
+
+func TestSolve(t *testing.T) {
+	board := Board{
+		{"c", "a", "t"},
+		{"x", "r", "x"},
+		{"x", "x", "s"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{
+		"cat",  // Straight line.
+		"car",  // Turns a corner.
+		"arc",  // Diagonal step.
+		"tars", // Doubles back on itself.
+		"cats", // Tiles not adjacent.
+		"tart", // Reuses a tile.
+		"dog",  // Letters not on board.
+		"xx",   // Many paths.
+	} {
+		dict.Insert(s)
+	}
+
+	want := []Solution{
+		{"arc", []Path{{{1, 0}, {1, 1}, {0, 0}}}},
+		{"car", []Path{{{0, 0}, {1, 0}, {1, 1}}}},
+		{"cat", []Path{{{0, 0}, {1, 0}, {2, 0}}}},
+		{"tars", []Path{{{2, 0}, {1, 0}, {1, 1}, {2, 2}}}},
+		{"xx", []Path{
+			{{0, 1}, {0, 2}},
+			{{0, 1}, {1, 2}},
+			{{2, 1}, {1, 2}},
+			{{0, 2}, {0, 1}},
+			{{0, 2}, {1, 2}},
+			{{1, 2}, {0, 1}},
+			{{1, 2}, {2, 1}},
+			{{1, 2}, {0, 2}},
+		}},
+	}
+	assert.Equal(t, want, Solve(board, dict))
+	assert.Equal(t, want, Solve(board, NewDAWG(dict)), "unexpected solutions with DAWG")
+	assert.Equal(t, want, Solve(board, wordSet(dict.Words())), "unexpected solutions with other lexicon")
+
+	// Nil dict.
+	assert.Empty(t, Solve(board, nil), "unexpected solutions with nil dict")
+
+	// Empty board.
+	assert.Empty(t, Solve(Board{}, dict), "unexpected solutions on empty board")
+}
+
+func TestSolve_MultiLetterTiles(t *testing.T) {
+	board := Board{
+		{"qu", "i"},
+		{"t", "e"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{
+		"quit",
+		"quiet",
+		"tie",
+		"qi", // Half of a tile.
+		"ut", // Starts part way through a tile.
+	} {
+		dict.Insert(s)
+	}
+
+	want := []Solution{
+		{"quiet", []Path{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}},
+		{"quit", []Path{{{0, 0}, {1, 0}, {0, 1}}}},
+		{"tie", []Path{{{0, 1}, {1, 0}, {1, 1}}}},
+	}
+	assert.Equal(t, want, Solve(board, dict))
+}
+
+func TestSolve_Blocked(t *testing.T) {
+	// A diamond with the corners blocked.
+	board, err := ParseBoard([]string{
+		"#c#",
+		"tao",
+		"#t",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dict := &Dict{}
+	for _, s := range []string{"cat", "coat", "taco", "tact"} {
+		dict.Insert(s)
+	}
+
+	want := []Solution{
+		{"cat", []Path{{{1, 0}, {1, 1}, {0, 1}}, {{1, 0}, {1, 1}, {1, 2}}}},
+		{"coat", []Path{{{1, 0}, {2, 1}, {1, 1}, {0, 1}}, {{1, 0}, {2, 1}, {1, 1}, {1, 2}}}},
+		{"taco", []Path{{{0, 1}, {1, 1}, {1, 0}, {2, 1}}, {{1, 2}, {1, 1}, {1, 0}, {2, 1}}}},
+		{"tact", []Path{{{1, 2}, {1, 1}, {1, 0}, {0, 1}}}},
+	}
+	assert.Equal(t, want, Solve(board, dict))
+	assert.Empty(t, Solve(Board{{Blocked, Blocked}}, dict))
+}
+
+func TestSolve_Wildcard(t *testing.T) {
+	board := Board{
+		{"c", Wildcard, "t"},
+		{Blocked, Blocked, "s"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{
+		"cat", "cot", "cut", // Wildcard stands for different letters.
+		"cats", // Wildcard in the middle.
+		"tact", // Reuses a tile.
+	} {
+		dict.Insert(s)
+	}
+
+	want := []Solution{
+		{"cat", []Path{{{0, 0}, {1, 0}, {2, 0}}}},
+		{"cats", []Path{{{0, 0}, {1, 0}, {2, 0}, {2, 1}}}},
+		{"cot", []Path{{{0, 0}, {1, 0}, {2, 0}}}},
+		{"cut", []Path{{{0, 0}, {1, 0}, {2, 0}}}},
+	}
+	assert.Equal(t, want, Solve(board, dict))
+	assert.Equal(t, want, Solve(board, NewDAWG(dict)), "unexpected solutions with DAWG")
+	assert.Equal(t, want, Solve(board, wordSet(dict.Words())), "unexpected solutions with other lexicon")
+}
+
+func TestSolver(t *testing.T) {
+	dict := &Dict{}
+	for _, s := range []string{"ate", "eat", "tea", "teas", "seat", "east", "sat", "rat", "rats", "star", "tsar"} {
+		dict.Insert(s)
+	}
+	rng := rand.New(rand.NewSource(1))
+	board := make(Board, 16)
+	for y := range board {
+		board[y] = make([]Tile, 16)
+		for x := range board[y] {
+			board[y][x] = Tile("aerst"[rng.Intn(5)])
+		}
+	}
+	want := Solve(board, dict)
+	assert.NotEmpty(t, want)
+
+	for _, workers := range []int{0, 1, 3, 64} {
+		for name, lex := range map[string]Lexicon{"Dict": dict, "DAWG": NewDAWG(dict)} {
+			got, err := Solver{Workers: workers}.Solve(context.Background(), board, lex)
+			assert.NoError(t, err)
+			assert.Equalf(t, want, got, "unexpected solutions with %s and %d workers", name, workers)
+		}
+	}
+
+	// Cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Solver{}.Solve(ctx, board, dict)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Deadline exceeded.
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	_, err = Solver{}.Solve(ctx, board, dict)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Cancelled part way.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	_, err = Solver{Workers: 1}.Solve(ctx, board, &cancelAfter{wordSet(dict.Words()), 100, cancel})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Empty board.
+	got, err := Solver{}.Solve(context.Background(), Board{}, dict)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -0,0 +1,97 @@
+package boggle
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tile is the face of a die, which is usually a single letter but may
+// be more than one, e.g. "qu".
+type Tile string
+
+// Blocked is a tile that is not part of the board, such as a blank die
+// face or a cell masked out of a shaped board. No word may use it.
+const Blocked Tile = "#"
+
+func (t Tile) IsBlocked() bool {
+	return t == Blocked
+}
+
+// Wildcard is a tile that stands for any one letter, as house rules may
+// allow. A blank die face is blocked rather than a wildcard.
+const Wildcard Tile = "*"
+
+func (t Tile) IsWildcard() bool {
+	return t == Wildcard
+}
+
+// ParseRow parses a row of tiles, where tiles of more than one letter
+// are enclosed in brackets, blocked tiles are '#' and wildcard tiles
+// are '*', e.g. "ab[qu]#*c".
+func ParseRow(s string) ([]Tile, error) {
+	var (
+		row   []Tile
+		runes = []rune(s)
+	)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '[':
+			j := i + 1
+			for j < len(runes) && runes[j] != ']' {
+				if runes[j] == '[' || runes[j] == '#' || runes[j] == '*' {
+					return nil, fmt.Errorf("unexpected '%c' at %d", runes[j], j)
+				}
+				j++
+			}
+			if j == len(runes) {
+				return nil, fmt.Errorf("unclosed '[' at %d", i)
+			}
+			if j == i+1 {
+				return nil, fmt.Errorf("empty tile at %d", i)
+			}
+			row = append(row, Tile(runes[i+1:j]))
+			i = j
+		case ']':
+			return nil, fmt.Errorf("unexpected ']' at %d", i)
+		default:
+			row = append(row, Tile(runes[i]))
+		}
+	}
+	return row, nil
+}
+
+// FormatRow formats a row of tiles so that it can be parsed by ParseRow.
+func FormatRow(row []Tile) string {
+	var b strings.Builder
+	for _, t := range row {
+		if len([]rune(t)) == 1 {
+			b.WriteString(string(t))
+		} else {
+			b.WriteRune('[')
+			b.WriteString(string(t))
+			b.WriteRune(']')
+		}
+	}
+	return b.String()
+}
+
+// ParseBoard parses each row of a board with ParseRow.
+func ParseBoard(rows []string) (Board, error) {
+	b := make(Board, len(rows))
+	for i, s := range rows {
+		var err error
+		if b[i], err = ParseRow(s); err != nil {
+			return nil, fmt.Errorf("invalid row %d: %w", i, err)
+		}
+	}
+	return b, nil
+}
+
+// Format formats each row of a board with FormatRow.
+func (b Board) Format() []string {
+	rows := make([]string, len(b))
+	for i, row := range b {
+		rows[i] = FormatRow(row)
+	}
+	return rows
+}
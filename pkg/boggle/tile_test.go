@@ -0,0 +1,57 @@
+package boggle
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseRow(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		row  []Tile
+		err  bool
+	}{
+		{"empty", "", nil, false},
+		{"single", "abcd", []Tile{"a", "b", "c", "d"}, false},
+		{"multi", "[qu][th]", []Tile{"qu", "th"}, false},
+		{"mixed", "a[qu]b", []Tile{"a", "qu", "b"}, false},
+		{"unicode", "é[ñé]", []Tile{"é", "ñé"}, false},
+		{"blocked", "a#[qu]", []Tile{"a", Blocked, "qu"}, false},
+		{"blocked in tile", "[q#]", nil, true},
+		{"wildcard", "a*[qu]", []Tile{"a", Wildcard, "qu"}, false},
+		{"wildcard in tile", "[q*]", nil, true},
+		{"unclosed", "a[qu", nil, true},
+		{"unopened", "aqu]", nil, true},
+		{"nested", "[q[u]]", nil, true},
+		{"empty tile", "a[]", nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			row, err := ParseRow(test.s)
+			if test.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.row, row)
+			assert.Equal(t, test.s, FormatRow(row), "unexpected round trip")
+		})
+	}
+}
+
+func TestParseBoard(t *testing.T) {
+	board, err := ParseBoard([]string{"ab", "[qu]c"})
+	assert.NoError(t, err)
+	assert.Equal(t, Board{{"a", "b"}, {"qu", "c"}}, board)
+	assert.Equal(t, []string{"ab", "[qu]c"}, board.Format())
+
+	_, err = ParseBoard([]string{"ab", "[qu"})
+	assert.ErrorContains(t, err, "row 1")
+
+	// Shaped boards keep their blocked tiles and ragged rows.
+	board, err = ParseBoard([]string{"#a#", "bcd", "#e"})
+	assert.NoError(t, err)
+	assert.Equal(t, Board{{Blocked, "a", Blocked}, {"b", "c", "d"}, {Blocked, "e"}}, board)
+	assert.Equal(t, []string{"#a#", "bcd", "#e"}, board.Format())
+}
@@ -0,0 +1,119 @@
+package boggle
+
+import "fmt"
+
+// Topology decides which tiles of a board are adjacent.
+type Topology interface {
+	// Neighbours returns the points on the board adjacent to p, which
+	// never include p itself.
+	Neighbours(b Board, p Point) []Point
+}
+
+// Grid is a grid of square tiles where tiles are adjacent horizontally
+// and vertically, and optionally diagonally. If Wrap is set then the
+// edges of the board are joined to make a torus.
+type Grid struct {
+	Diagonal bool
+	Wrap     bool
+}
+
+func (g Grid) Neighbours(b Board, p Point) []Point {
+	q := make([]Point, 0, 8)
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 || !g.Diagonal && dx != 0 && dy != 0 {
+				continue
+			}
+			n := Point{p.X() + dx, p.Y() + dy}
+			if g.Wrap {
+				n = b.wrap(n)
+			}
+			if n != p && b.Contains(n) && !containsPoint(q, n) {
+				q = append(q, n)
+			}
+		}
+	}
+	return q
+}
+
+// wrap returns p wrapped around the rows of the board and then around
+// the tiles of its row.
+func (b Board) wrap(p Point) Point {
+	if len(b) == 0 {
+		return p
+	}
+	y := mod(p.Y(), len(b))
+	if len(b[y]) == 0 {
+		return Point{p.X(), y}
+	}
+	return Point{mod(p.X(), len(b[y])), y}
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
+
+func containsPoint(q []Point, p Point) bool {
+	for _, n := range q {
+		if n == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Hex is a grid of hexagonal tiles in offset rows, where every odd row
+// is shifted right by half a tile. Each tile is adjacent to up to two
+// tiles in its own row and two in each of the rows above and below.
+type Hex struct{}
+
+func (Hex) Neighbours(b Board, p Point) []Point {
+	x, y := p.X(), p.Y()
+	shift := y & 1
+	q := make([]Point, 0, 6)
+	for _, n := range []Point{
+		{x - 1, y},
+		{x + 1, y},
+		{x - 1 + shift, y - 1},
+		{x + shift, y - 1},
+		{x - 1 + shift, y + 1},
+		{x + shift, y + 1},
+	} {
+		if b.Contains(n) {
+			q = append(q, n)
+		}
+	}
+	return q
+}
+
+var (
+	// Square is the classic topology where tiles are adjacent in all eight directions.
+	Square = Grid{Diagonal: true}
+	// Orthogonal is a topology where tiles are adjacent in four directions.
+	Orthogonal = Grid{}
+	// Torus is the classic topology with the edges of the board joined.
+	Torus = Grid{Diagonal: true, Wrap: true}
+)
+
+// Topologies are the named topologies.
+var Topologies = map[string]Topology{
+	"square":           Square,
+	"orthogonal":       Orthogonal,
+	"torus":            Torus,
+	"orthogonal-torus": Grid{Wrap: true},
+	"hex":              Hex{},
+}
+
+// ParseTopology returns the named topology.
+func ParseTopology(s string) (Topology, error) {
+	t, ok := Topologies[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown topology '%s'", s)
+	}
+	return t, nil
+}
+
+// IsAdjacent returns true if q is a neighbour of p in the topology.
+func IsAdjacent(t Topology, b Board, p, q Point) bool {
+	return containsPoint(t.Neighbours(b, p), q)
+}
@@ -0,0 +1,91 @@
+package boggle
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTopology_Neighbours(t *testing.T) {
+	board := Board{
+		{"a", "b", "c"},
+		{"d", "e", "f"},
+		{"g", "h", "i"},
+	}
+	tests := []struct {
+		name     string
+		topology Topology
+		p        Point
+		want     []Point
+	}{
+		{"SquareCentre", Square, Point{1, 1}, []Point{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}, {0, 2}, {1, 2}, {2, 2}}},
+		{"SquareCorner", Square, Point{0, 0}, []Point{{1, 0}, {0, 1}, {1, 1}}},
+		{"OrthogonalCentre", Orthogonal, Point{1, 1}, []Point{{1, 0}, {0, 1}, {2, 1}, {1, 2}}},
+		{"OrthogonalCorner", Orthogonal, Point{0, 0}, []Point{{1, 0}, {0, 1}}},
+		{"TorusCorner", Torus, Point{0, 0}, []Point{{2, 2}, {0, 2}, {1, 2}, {2, 0}, {1, 0}, {2, 1}, {0, 1}, {1, 1}}},
+		{"OrthogonalTorusCorner", Topologies["orthogonal-torus"], Point{0, 0}, []Point{{0, 2}, {2, 0}, {1, 0}, {0, 1}}},
+		{"HexEvenRow", Hex{}, Point{1, 0}, []Point{{0, 0}, {2, 0}, {0, 1}, {1, 1}}},
+		{"HexOddRow", Hex{}, Point{1, 1}, []Point{{0, 1}, {2, 1}, {1, 0}, {2, 0}, {1, 2}, {2, 2}}},
+		{"OffBoard", Square, Point{5, 5}, []Point{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ElementsMatch(t, test.want, test.topology.Neighbours(board, test.p))
+		})
+	}
+
+	// Wrapping a small board does not repeat neighbours or include the point itself.
+	assert.ElementsMatch(t, []Point{{1, 0}, {0, 1}, {1, 1}}, Torus.Neighbours(Board{{"a", "b"}, {"c", "d"}}, Point{0, 0}))
+	assert.Empty(t, Torus.Neighbours(Board{{"a"}}, Point{0, 0}))
+}
+
+func TestParseTopology(t *testing.T) {
+	for name, want := range Topologies {
+		got, err := ParseTopology(name)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := ParseTopology("klein")
+	assert.Error(t, err)
+}
+
+func TestSolver_Topology(t *testing.T) {
+	board := Board{
+		{"c", "a", "x"},
+		{"x", "t", "x"},
+		{"x", "x", "x"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{"cat", "act", "tax"} {
+		dict.Insert(s)
+	}
+	words := func(topology Topology) []string {
+		solutions, err := Solver{Topology: topology}.Solve(context.Background(), board, dict)
+		assert.NoError(t, err)
+		var s []string
+		for _, solution := range solutions {
+			s = append(s, solution.Word)
+		}
+		return s
+	}
+	assert.Equal(t, []string{"act", "cat", "tax"}, words(nil))
+	assert.Equal(t, []string{"cat", "tax"}, words(Orthogonal)) // "act" needs the diagonal c-t.
+	assert.Equal(t, []string{"act", "cat", "tax"}, words(Torus))
+}
+
+func TestValidator_Topology(t *testing.T) {
+	board := Board{
+		{"t", "x", "c"},
+		{"x", "x", "a"},
+	}
+	dict := &Dict{}
+	dict.Insert("cat")
+	path := Path{{2, 0}, {2, 1}, {0, 0}} // Wraps from the right edge to the left.
+
+	_, err := Validator{Dict: dict, MinLength: MinLength}.Validate(board, path)
+	assert.Equal(t, &PathError{RuleAdjacent, 2, Point{0, 0}}, err)
+
+	word, err := Validator{Dict: dict, MinLength: MinLength, Topology: Torus}.Validate(board, path)
+	assert.NoError(t, err)
+	assert.Equal(t, "cat", word)
+}
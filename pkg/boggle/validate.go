@@ -0,0 +1,177 @@
+package boggle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// MinLength is the fewest letters a word may have under the classic rules.
+const MinLength = 3
+
+// Rule identifies a rule that a path must satisfy to spell a valid word.
+type Rule string
+
+const (
+	RuleBounds    Rule = "BOUNDS"
+	RuleBlocked   Rule = "BLOCKED"
+	RuleAdjacent  Rule = "ADJACENT"
+	RuleReuse     Rule = "REUSE"
+	RuleMinLength Rule = "MIN_LENGTH"
+	RuleClaim     Rule = "CLAIM"
+	RuleDict      Rule = "DICT"
+)
+
+// PathError describes which rule a path failed and at which point.
+//
+// Index is -1 if the rule applies to the path as a whole.
+type PathError struct {
+	Rule  Rule
+	Index int
+	Point Point
+}
+
+func (e *PathError) Error() string {
+	switch e.Rule {
+	case RuleBounds:
+		return fmt.Sprintf("point %d %v is not on the board", e.Index, e.Point)
+	case RuleBlocked:
+		return fmt.Sprintf("point %d %v is a blocked tile", e.Index, e.Point)
+	case RuleAdjacent:
+		return fmt.Sprintf("point %d %v is not adjacent to point %d", e.Index, e.Point, e.Index-1)
+	case RuleReuse:
+		return fmt.Sprintf("point %d %v reuses a tile", e.Index, e.Point)
+	case RuleMinLength:
+		return "path is too short"
+	case RuleClaim:
+		if e.Index < 0 {
+			return "path does not spell the claimed word"
+		}
+		return fmt.Sprintf("point %d %v does not match the claimed word", e.Index, e.Point)
+	case RuleDict:
+		if e.Index < 0 {
+			return "path does not spell a word in the dictionary"
+		}
+		return fmt.Sprintf("no word in the dictionary starts with the path up to point %d %v", e.Index, e.Point)
+	default:
+		return fmt.Sprintf("path breaks rule %s", e.Rule)
+	}
+}
+
+// Spell returns the word spelled by a path, checking that every point
+// is on the board and not blocked, adjacent to the previous point in
+// the topology and used at most once. Wildcard tiles are spelled '*'.
+// If the topology is nil then it is Square.
+func (b Board) Spell(t Topology, path Path) (string, error) {
+	if t == nil {
+		t = Square
+	}
+	var word strings.Builder
+	seen := make(map[Point]bool, len(path))
+	for i, p := range path {
+		switch {
+		case !b.Contains(p):
+			return "", &PathError{RuleBounds, i, p}
+		case b.At(p).IsBlocked():
+			return "", &PathError{RuleBlocked, i, p}
+		case seen[p]:
+			return "", &PathError{RuleReuse, i, p}
+		case i > 0 && !IsAdjacent(t, b, path[i-1], p):
+			return "", &PathError{RuleAdjacent, i, p}
+		}
+		seen[p] = true
+		word.WriteString(string(b.At(p)))
+	}
+	return word.String(), nil
+}
+
+// Resolve returns the tiles of a path as Spell checks them, where each
+// wildcard tile is replaced by the letter that it stands for in the
+// claimed word. If the path has no wildcards then the claim may be empty.
+func (b Board) Resolve(t Topology, path Path, claim string) ([]Tile, error) {
+	if _, err := b.Spell(t, path); err != nil {
+		return nil, err
+	}
+	tiles := make([]Tile, len(path))
+	rest := claim
+	for i, p := range path {
+		tile := b.At(p)
+		switch {
+		case claim == "" && tile.IsWildcard():
+			return nil, &PathError{RuleClaim, i, p}
+		case claim == "":
+		case tile.IsWildcard():
+			c, n := utf8.DecodeRuneInString(rest)
+			if n == 0 {
+				return nil, &PathError{RuleClaim, i, p}
+			}
+			tile, rest = Tile(c), rest[n:]
+		case strings.HasPrefix(rest, string(tile)):
+			rest = rest[len(tile):]
+		default:
+			return nil, &PathError{RuleClaim, i, p}
+		}
+		tiles[i] = tile
+	}
+	if rest != "" {
+		return nil, &PathError{Rule: RuleClaim, Index: -1}
+	}
+	return tiles, nil
+}
+
+// Validator checks that paths spell valid words on a board.
+//
+// If Dict is nil, or a nil pointer such as a nil *Dict, then dictionary
+// membership is not checked, and if Topology is nil then it is Square.
+type Validator struct {
+	Dict      Lexicon
+	MinLength int
+	Topology  Topology
+}
+
+// isNil returns whether a lexicon is nil or a nil pointer, which is not
+// nil as an interface.
+func isNil(l Lexicon) bool {
+	if l == nil {
+		return true
+	}
+	v := reflect.ValueOf(l)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// Validate returns the word spelled by a path, or a *PathError
+// describing the first rule the path breaks. The path must not cross
+// any wildcard tiles.
+func (v Validator) Validate(board Board, path Path) (string, error) {
+	return v.ValidateClaim(board, path, "")
+}
+
+// ValidateClaim is like Validate but the path spells the claimed word,
+// which decides the letters of any wildcard tiles.
+func (v Validator) ValidateClaim(board Board, path Path, claim string) (string, error) {
+	tiles, err := board.Resolve(v.Topology, path, claim)
+	if err != nil {
+		return "", err
+	}
+	var word strings.Builder
+	for _, t := range tiles {
+		word.WriteString(string(t))
+	}
+	if utf8.RuneCountInString(word.String()) < v.MinLength {
+		return "", &PathError{Rule: RuleMinLength, Index: -1}
+	}
+	if !isNil(v.Dict) {
+		var prefix strings.Builder
+		for i, p := range path {
+			prefix.WriteString(string(tiles[i]))
+			if !v.Dict.HasPrefix(prefix.String()) {
+				return "", &PathError{RuleDict, i, p}
+			}
+		}
+		if !v.Dict.Contains(word.String()) {
+			return "", &PathError{Rule: RuleDict, Index: -1}
+		}
+	}
+	return word.String(), nil
+}
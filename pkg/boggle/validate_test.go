@@ -0,0 +1,148 @@
+package boggle
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	board := Board{
+		{"c", "a", "t"},
+		{"x", "r", "x"},
+		{"x", "x", "s"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{"cat", "car", "tars", "at"} {
+		dict.Insert(s)
+	}
+	validator := Validator{Dict: dict, MinLength: MinLength}
+
+	tests := []struct {
+		name string
+		path Path
+		word string
+		err  *PathError
+	}{
+		{"ok", Path{{0, 0}, {1, 0}, {2, 0}}, "cat", nil},
+		{"ok diagonal", Path{{2, 0}, {1, 0}, {1, 1}, {2, 2}}, "tars", nil},
+		{"bounds negative", Path{{0, 0}, {-1, 0}}, "", &PathError{RuleBounds, 1, Point{-1, 0}}},
+		{"bounds overflow", Path{{0, 0}, {1, 0}, {2, 0}, {3, 0}}, "", &PathError{RuleBounds, 3, Point{3, 0}}},
+		{"not adjacent", Path{{2, 0}, {2, 2}}, "", &PathError{RuleAdjacent, 1, Point{2, 2}}},
+		{"reuse", Path{{0, 0}, {1, 0}, {0, 0}}, "", &PathError{RuleReuse, 2, Point{0, 0}}},
+		{"too short", Path{{1, 0}, {2, 0}}, "", &PathError{Rule: RuleMinLength, Index: -1}},
+		{"empty", Path{}, "", &PathError{Rule: RuleMinLength, Index: -1}},
+		{"not prefix", Path{{0, 0}, {1, 1}, {2, 2}}, "", &PathError{RuleDict, 1, Point{1, 1}}},
+		{"not word", Path{{0, 0}, {1, 0}, {2, 1}}, "", &PathError{RuleDict, 2, Point{2, 1}}},
+		{"prefix not word", Path{{2, 0}, {1, 0}, {1, 1}}, "", &PathError{Rule: RuleDict, Index: -1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			word, err := validator.Validate(board, test.path)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, test.err, err)
+			}
+			assert.Equal(t, test.word, word)
+		})
+	}
+}
+
+func TestValidator_MultiLetterTiles(t *testing.T) {
+	board := Board{
+		{"qu", "i"},
+		{"t", "e"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{"quit", "qat"} {
+		dict.Insert(s)
+	}
+	validator := Validator{Dict: dict, MinLength: MinLength}
+
+	word, err := validator.Validate(board, Path{{0, 0}, {1, 0}, {0, 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, "quit", word)
+
+	// A tile of more than one letter counts towards the minimum length.
+	_, err = validator.Validate(board, Path{{0, 0}, {1, 0}})
+	assert.Equal(t, &PathError{Rule: RuleDict, Index: -1}, err)
+
+	// A tile of more than one letter is consumed whole.
+	_, err = validator.Validate(board, Path{{0, 0}, {0, 1}})
+	assert.Equal(t, &PathError{RuleDict, 1, Point{0, 1}}, err)
+}
+
+func TestValidator_NilDict(t *testing.T) {
+	board := Board{{"x", "y", "z"}}
+	for name, dict := range map[string]Lexicon{"Nil": nil, "NilDict": (*Dict)(nil), "NilDAWG": (*DAWG)(nil)} {
+		t.Run(name, func(t *testing.T) {
+			word, err := Validator{Dict: dict, MinLength: MinLength}.Validate(board, Path{{0, 0}, {1, 0}, {2, 0}})
+			assert.NoError(t, err)
+			assert.Equal(t, "xyz", word)
+		})
+	}
+}
+
+func TestValidator_Blocked(t *testing.T) {
+	board := Board{
+		{"c", "#", "t"},
+		{"#", "a"},
+	}
+	dict := &Dict{}
+	dict.Insert("cat")
+	validator := Validator{Dict: dict, MinLength: MinLength}
+
+	word, err := validator.Validate(board, Path{{0, 0}, {1, 1}, {2, 0}})
+	assert.NoError(t, err)
+	assert.Equal(t, "cat", word)
+
+	_, err = validator.Validate(board, Path{{0, 0}, {1, 0}, {2, 0}})
+	assert.Equal(t, &PathError{RuleBlocked, 1, Point{1, 0}}, err)
+
+	// Ragged rows are shorter than the board is wide.
+	_, err = validator.Validate(board, Path{{0, 0}, {1, 1}, {2, 1}})
+	assert.Equal(t, &PathError{RuleBounds, 2, Point{2, 1}}, err)
+}
+
+func TestValidator_Wildcard(t *testing.T) {
+	board := Board{
+		{"c", Wildcard, "t"},
+		{"x", "x", "qu"},
+	}
+	dict := &Dict{}
+	for _, s := range []string{"cat", "cot"} {
+		dict.Insert(s)
+	}
+	validator := Validator{Dict: dict, MinLength: MinLength}
+	path := Path{{0, 0}, {1, 0}, {2, 0}}
+
+	tests := []struct {
+		name  string
+		path  Path
+		claim string
+		word  string
+		err   *PathError
+	}{
+		{"ok", path, "cat", "cat", nil},
+		{"ok other letter", path, "cot", "cot", nil},
+		{"no claim", path, "", "", &PathError{RuleClaim, 1, Point{1, 0}}},
+		{"wrong letter", path, "cab", "", &PathError{RuleClaim, 2, Point{2, 0}}},
+		{"claim too short", path, "ca", "", &PathError{RuleClaim, 2, Point{2, 0}}},
+		{"claim too long", path, "cats", "", &PathError{Rule: RuleClaim, Index: -1}},
+		{"multi-letter tile", Path{{1, 0}, {2, 1}}, "aqu", "", &PathError{RuleDict, 0, Point{1, 0}}},
+		{"part of multi-letter tile", Path{{1, 0}, {2, 1}}, "aq", "", &PathError{RuleClaim, 1, Point{2, 1}}},
+		{"not prefix", path, "cut", "", &PathError{RuleDict, 1, Point{1, 0}}},
+		{"bounds first", Path{{0, 0}, {1, 0}, {3, 0}}, "cat", "", &PathError{RuleBounds, 2, Point{3, 0}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			word, err := validator.ValidateClaim(board, test.path, test.claim)
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, test.err, err)
+			}
+			assert.Equal(t, test.word, word)
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package database
 
 import (
+	"fmt"
+	"strings"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
@@ -20,5 +24,64 @@ func Open(dsn string) (*DB, error) {
 }
 
 func Migrate(db *DB) error {
-	return db.AutoMigrate(&Game{}, &Word{}, &Player{}, &WordPlayer{})
+	if err := migrateWordText(db); err != nil {
+		return fmt.Errorf("error migrating word text: %w", err)
+	}
+	// Words are unique by their text too, as a path with wildcards may
+	// spell many.
+	if err := migrateIndex(db, "words", "idx_word", "text"); err != nil {
+		return fmt.Errorf("error migrating word index: %w", err)
+	}
+	// Hosts may spectate.
+	if err := migrateIndex(db, "game_players", "idx_game_host", string(RoleSpectatingHost)); err != nil {
+		return fmt.Errorf("error migrating game host index: %w", err)
+	}
+	return db.AutoMigrate(&Dictionary{}, &DictionaryWord{}, &Game{}, &Word{}, &Player{}, &WordPlayer{}, &Session{}, &GamePlayer{})
+}
+
+// migrateWordText adds the text column to a words table that was made
+// before words had text. AutoMigrate cannot add it as it is not null, so
+// it is added as null, filled in from the boards of the games, and then
+// made not null.
+func migrateWordText(db *DB) error {
+	m := db.Migrator()
+	if !m.HasTable(&Word{}) || m.HasColumn(&Word{}, "Text") {
+		return nil
+	}
+	return db.Transaction(func(tx *DB) error {
+		if err := tx.Exec("ALTER TABLE words ADD COLUMN text text").Error; err != nil {
+			return err
+		}
+		var words []Word
+		err := tx.Model(&Word{}).Select("id", "game_id", "path").Preload("Game").
+			FindInBatches(&words, 100, func(*DB, int) error {
+				for i := range words {
+					if err := words[i].spell(tx); err != nil {
+						return fmt.Errorf("error spelling word %d: %w", words[i].ID, err)
+					}
+					if err := tx.Model(&words[i]).Update("text", words[i].Text).Error; err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Exec("ALTER TABLE words ALTER COLUMN text SET NOT NULL").Error
+	})
+}
+
+// migrateIndex drops an index of a table if it was made before its
+// definition included want, so that AutoMigrate makes it again.
+func migrateIndex(db *DB, table, name, want string) error {
+	var def string
+	err := db.Raw("SELECT indexdef FROM pg_indexes WHERE tablename = ? AND indexname = ?", table, name).Scan(&def).Error
+	if err != nil {
+		return err
+	}
+	if def == "" || strings.Contains(def, want) {
+		return nil
+	}
+	return db.Exec("DROP INDEX ?", clause.Column{Name: name}).Error
 }
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateWordText(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		var game Game
+		game.LoadBoard(boggle.Board{{"a", "b"}, {"c", "qu"}})
+		if err := tx.WithContext(ctx).Create(&game).Error; err != nil {
+			t.Fatalf("create game error: %v", err)
+		}
+		word := Word{GameID: game.ID, Path: Path{{0, 0}, {1, 1}, {1, 0}}}
+		if err := tx.WithContext(ctx).Create(&word).Error; err != nil {
+			t.Fatalf("create word error: %v", err)
+		}
+		// Words had no text before.
+		if err := tx.WithContext(ctx).Exec("ALTER TABLE words DROP COLUMN text").Error; err != nil {
+			t.Fatalf("drop text error: %v", err)
+		}
+
+		assert.NoError(t, migrateWordText(tx.WithContext(ctx)))
+		var found Word
+		if assert.NoError(t, tx.WithContext(ctx).First(&found, word.ID).Error) {
+			assert.Equal(t, "aqub", found.Text)
+		}
+		assert.Error(t, tx.WithContext(ctx).Exec("UPDATE words SET text = NULL WHERE id = ?", word.ID).Error)
+	})
+}
+
+func TestMigrateIndex(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		tx = tx.WithContext(ctx)
+		// Indexes as they were made before.
+		for _, stmt := range []string{
+			"DROP INDEX idx_word",
+			"CREATE UNIQUE INDEX idx_word ON words (game_id, path)",
+			"DROP INDEX idx_game_host",
+			"CREATE UNIQUE INDEX idx_game_host ON game_players (game_id) WHERE role = 'HOST'",
+		} {
+			if err := tx.Exec(stmt).Error; err != nil {
+				t.Fatalf("%s error: %v", stmt, err)
+			}
+		}
+
+		assert.NoError(t, Migrate(tx))
+		for name, want := range map[string]string{"idx_word": "text", "idx_game_host": "SPECTATING_HOST"} {
+			var def string
+			assert.NoError(t, tx.Raw("SELECT indexdef FROM pg_indexes WHERE indexname = ?", name).Scan(&def).Error)
+			assert.Contains(t, def, want)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/phyrwork/bogglr/pkg/boggle"
+)
+
+type Dictionary struct {
+	ID        int              `gorm:"primaryKey;not null"`
+	Name      string           `gorm:"not null;uniqueIndex:idx_dictionary"`
+	Language  string           `gorm:"not null"`
+	Version   string           `gorm:"not null;uniqueIndex:idx_dictionary"`
+	WordCount int              `gorm:"not null"`
+	Hash      string           `gorm:"not null;type:char(64)"`
+	Words     []DictionaryWord `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type DictionaryWord struct {
+	DictionaryID int `gorm:"primaryKey;not null"`
+	Dictionary   *Dictionary
+	Word         string `gorm:"primaryKey;not null"`
+}
+
+// HashWords returns the SHA-256 hash of the sorted words, each
+// terminated by a newline, as a hex string.
+func HashWords(words []string) string {
+	sorted := make([]string, len(words))
+	copy(sorted, words)
+	sort.Strings(sorted)
+	h := sha256.New()
+	for _, s := range sorted {
+		h.Write([]byte(s))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LoadDict sets the words of the dictionary, along with its word count and hash.
+func (d *Dictionary) LoadDict(dict *boggle.Dict) {
+	words := make([]string, 0, dict.Len())
+	for s := range dict.Words() {
+		words = append(words, s)
+	}
+	sort.Strings(words)
+	d.Words = make([]DictionaryWord, len(words))
+	for i, s := range words {
+		d.Words[i] = DictionaryWord{DictionaryID: d.ID, Word: s}
+	}
+	d.WordCount = len(words)
+	d.Hash = HashWords(words)
+}
+
+func (d *Dictionary) DumpDict() *boggle.Dict {
+	dict := &boggle.Dict{}
+	for _, w := range d.Words {
+		dict.Insert(w.Word)
+	}
+	return dict
+}
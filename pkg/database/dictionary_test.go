@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDictionary_LoadDict(t *testing.T) {
+	dict := &boggle.Dict{}
+	for _, s := range []string{"who", "what", "why"} {
+		dict.Insert(s)
+	}
+	var dictionary Dictionary
+	dictionary.LoadDict(dict)
+
+	assert.Equal(t, 3, dictionary.WordCount)
+	assert.Equal(t, HashWords([]string{"why", "who", "what"}), dictionary.Hash, "hash should not depend on order")
+	assert.NotEqual(t, HashWords([]string{"who", "what"}), dictionary.Hash, "hash should depend on words")
+	assert.Equal(t, dict.Words(), dictionary.DumpDict().Words())
+}
+
+func TestCreateDictionary(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		dict := &boggle.Dict{}
+		for _, s := range []string{"who", "what", "why"} {
+			dict.Insert(s)
+		}
+		dictionary := Dictionary{Name: "test", Language: "en", Version: "1"}
+		dictionary.LoadDict(dict)
+		if result := tx.WithContext(ctx).Create(&dictionary); result.Error != nil {
+			t.Fatalf("create dictionary error: %v", result.Error)
+		}
+
+		game := Game{DictionaryID: &dictionary.ID}
+		game.LoadBoard(boggle.Board{{"w", "h", "o"}})
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+
+		selected := Dictionary{ID: dictionary.ID}
+		if result := tx.WithContext(ctx).Preload("Words").Find(&selected); result.Error != nil {
+			t.Fatalf("select dictionary error: %v", result.Error)
+		}
+		assert.Equal(t, dictionary.WordCount, selected.WordCount)
+		assert.Equal(t, dictionary.Hash, selected.Hash)
+		assert.Equal(t, dict.Words(), selected.DumpDict().Words())
+	})
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"crypto/rand"
+	"time"
+)
+
+// GameRole is the part that a player has in a game.
+type GameRole string
+
+const (
+	RoleHost           GameRole = "HOST"
+	RolePlayer         GameRole = "PLAYER"
+	RoleSpectator      GameRole = "SPECTATOR"
+	RoleSpectatingHost GameRole = "SPECTATING_HOST"
+)
+
+// CanSubmit returns whether a player in the role may submit words.
+func (r GameRole) CanSubmit() bool {
+	return r == RoleHost || r == RolePlayer
+}
+
+// IsHost returns whether a player in the role manages the game.
+func (r GameRole) IsHost() bool {
+	return r == RoleHost || r == RoleSpectatingHost
+}
+
+// Hosting returns the role of a host who otherwise has the role, so
+// that a host who spectates still cannot submit words.
+func (r GameRole) Hosting() GameRole {
+	if r.CanSubmit() {
+		return RoleHost
+	}
+	return RoleSpectatingHost
+}
+
+// GamePlayer is the membership of a player in a game, of which there is
+// at most one host.
+type GamePlayer struct {
+	GameID   int `gorm:"primaryKey;not null;uniqueIndex:idx_game_host,where:role IN ('HOST'\\, 'SPECTATING_HOST')"`
+	Game     *Game
+	PlayerID int `gorm:"primaryKey;not null;index"`
+	Player   *Player
+	Role     GameRole  `gorm:"not null"`
+	JoinedAt time.Time `gorm:"not null"`
+}
+
+// InviteCodeLength is the number of characters in an invite code.
+const InviteCodeLength = 8
+
+// inviteAlphabet is the characters of invite codes, less those that are
+// easily mistaken for one another. Its length divides 256 so that each
+// character is as likely as any other.
+const inviteAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NewInviteCode returns a random code by which players join a game.
+func NewInviteCode() (string, error) {
+	b := make([]byte, InviteCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = inviteAlphabet[int(b[i])%len(inviteAlphabet)]
+	}
+	return string(b), nil
+}
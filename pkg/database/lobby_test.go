@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phyrwork/bogglr/pkg/boggle"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInviteCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := NewInviteCode()
+		assert.NoError(t, err)
+		assert.Len(t, code, InviteCodeLength)
+		for _, c := range code {
+			assert.Truef(t, strings.ContainsRune(inviteAlphabet, c), "unexpected '%c' in invite code", c)
+		}
+		assert.Falsef(t, seen[code], "repeated invite code '%s'", code)
+		seen[code] = true
+	}
+}
+
+func TestGameRole_CanSubmit(t *testing.T) {
+	assert.True(t, RoleHost.CanSubmit())
+	assert.True(t, RolePlayer.CanSubmit())
+	assert.False(t, RoleSpectator.CanSubmit())
+	assert.False(t, RoleSpectatingHost.CanSubmit())
+}
+
+func TestGameRole_Hosting(t *testing.T) {
+	assert.Equal(t, RoleHost, RolePlayer.Hosting())
+	assert.Equal(t, RoleHost, RoleHost.Hosting())
+	assert.Equal(t, RoleSpectatingHost, RoleSpectator.Hosting())
+	for _, role := range []GameRole{RoleHost, RoleSpectatingHost} {
+		assert.True(t, role.IsHost())
+	}
+	for _, role := range []GameRole{RolePlayer, RoleSpectator} {
+		assert.False(t, role.IsHost())
+	}
+}
+
+func TestCreateGamePlayer(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		var game Game
+		game.LoadBoard(boggle.Board{{"a", "b"}, {"c", "d"}})
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+		players := []Player{{Name: "alice"}, {Name: "bob"}}
+		if result := tx.WithContext(ctx).Create(&players); result.Error != nil {
+			t.Fatalf("create players error: %v", result.Error)
+		}
+		host := GamePlayer{GameID: game.ID, PlayerID: players[0].ID, Role: RoleHost, JoinedAt: time.Now()}
+		if result := tx.WithContext(ctx).Create(&host); result.Error != nil {
+			t.Fatalf("create host error: %v", result.Error)
+		}
+
+		// A game has at most one host.
+		other := GamePlayer{GameID: game.ID, PlayerID: players[1].ID, Role: RoleSpectatingHost, JoinedAt: time.Now()}
+		assert.Error(t, tx.WithContext(ctx).Transaction(func(tx *DB) error {
+			return tx.Create(&other).Error
+		}))
+		other.Role = RoleHost
+		assert.Error(t, tx.WithContext(ctx).Create(&other).Error)
+	})
+}
@@ -6,24 +6,24 @@ import (
 	"github.com/lib/pq"
 	"github.com/phyrwork/bogglr/pkg/boggle"
 	"github.com/phyrwork/bogglr/pkg/database/grammar"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 	"strings"
+	"time"
 )
 
+// MaxBoardSize is the greatest number of rows in a board and tiles in a row.
+const MaxBoardSize = 16
+
+// Board is a board stored as rows of tiles in the format of boggle.ParseRow.
 type Board pq.StringArray
 
 func (b *Board) Load(board boggle.Board) {
-	*b = make([]string, len(board))
-	for i, row := range board {
-		(*b)[i] = string(row)
-	}
+	*b = board.Format()
 }
 
-func (b Board) Dump() boggle.Board {
-	board := make([][]rune, len(b))
-	for i, row := range b {
-		board[i] = []rune(row)
-	}
-	return board
+func (b Board) Dump() (boggle.Board, error) {
+	return boggle.ParseBoard(b)
 }
 
 func (b *Board) Scan(src interface{}) error {
@@ -40,23 +40,89 @@ func (b Board) Value() (driver.Value, error) {
 }
 
 type Game struct {
-	ID    int   `gorm:"primaryKey;not null"`
-	Board Board `gorm:"not null;type:varchar(16)[16];check:cardinality(board) <= 16"`
+	ID           int   `gorm:"primaryKey;not null"`
+	Board        Board `gorm:"not null;type:varchar(64)[16];check:cardinality(board) <= 16"`
+	DiceSet      *string
+	Seed         *int64
+	Topology     *string
+	DictionaryID *int
+	Dictionary   *Dictionary
+	Duration     *int // Seconds, or nil if rounds are not timed.
+	StartedAt    *time.Time
+	EndsAt       *time.Time
+	// InviteCode is the code by which players join the game, or nil if
+	// it is not a lobby.
+	InviteCode *string `gorm:"uniqueIndex"`
 }
 
-func (g *Game) LoadBoard(board boggle.Board) {
-	g.Board = make([]string, len(board))
-	for i, row := range board {
-		g.Board[i] = string(row)
+// GameStatus is the stage of the round of a game.
+type GameStatus string
+
+const (
+	GameCreated  GameStatus = "CREATED"
+	GameRunning  GameStatus = "RUNNING"
+	GameFinished GameStatus = "FINISHED"
+)
+
+// StatusError is returned when a game is not in the status required.
+type StatusError struct {
+	Status GameStatus
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("game is %s", strings.ToLower(string(e.Status)))
+}
+
+// Status returns the status of the game at a time.
+func (g *Game) Status(now time.Time) GameStatus {
+	switch {
+	case g.StartedAt == nil:
+		return GameCreated
+	case g.EndsAt != nil && !now.Before(*g.EndsAt):
+		return GameFinished
+	default:
+		return GameRunning
 	}
 }
 
-func (g *Game) DumpBoard() boggle.Board {
-	board := make([][]rune, len(g.Board))
-	for i, row := range g.Board {
-		board[i] = []rune(row)
+// Start starts the round at a time, to end after the duration of the
+// game if it is timed.
+func (g *Game) Start(now time.Time) error {
+	if status := g.Status(now); status != GameCreated {
+		return &StatusError{status}
+	}
+	g.StartedAt = &now
+	if g.Duration != nil {
+		endsAt := now.Add(time.Duration(*g.Duration) * time.Second)
+		g.EndsAt = &endsAt
+	}
+	return nil
+}
+
+// Finish ends the round at a time, before the end of its duration.
+func (g *Game) Finish(now time.Time) error {
+	if status := g.Status(now); status != GameRunning {
+		return &StatusError{status}
+	}
+	g.EndsAt = &now
+	return nil
+}
+
+func (g *Game) LoadBoard(board boggle.Board) {
+	g.Board.Load(board)
+}
+
+func (g *Game) DumpBoard() (boggle.Board, error) {
+	return g.Board.Dump()
+}
+
+// DumpTopology returns the named topology of the game, or boggle.Square
+// if it has none.
+func (g *Game) DumpTopology() (boggle.Topology, error) {
+	if g.Topology == nil {
+		return boggle.Square, nil
 	}
-	return board
+	return boggle.ParseTopology(*g.Topology)
 }
 
 // Point is a PostgreSQL point (closed notation).
@@ -118,18 +184,97 @@ func (p Path) Value() (driver.Value, error) {
 	return b.String(), nil
 }
 
+func (p *Path) Load(path boggle.Path) {
+	*p = make(Path, len(path))
+	for i, point := range path {
+		(*p)[i] = Point(point)
+	}
+}
+
+func (p Path) Dump() boggle.Path {
+	path := make(boggle.Path, len(p))
+	for i, point := range p {
+		path[i] = boggle.Point(point)
+	}
+	return path
+}
+
 type Word struct {
 	ID      int `gorm:"primaryKey;not null"`
-	GameID  int `gorm:"not null;uniqueKey:idx_word"`
+	GameID  int `gorm:"not null;uniqueIndex:idx_word"`
 	Game    *Game
-	Path    Path     `gorm:"not null;uniqueKey:idx_word"`
+	Path    Path     `gorm:"not null;uniqueIndex:idx_word"`
+	Text    string   `gorm:"not null;index;uniqueIndex:idx_word"`
 	Players []Player `gorm:"many2many:word_players"`
 }
 
+// BeforeCreate derives the text of the word.
+func (w *Word) BeforeCreate(tx *DB) error {
+	return w.spell(tx)
+}
+
+// spell derives the text of the word from the game board, where any
+// wildcard tiles stand for the letters of the text already set.
+func (w *Word) spell(tx *DB) error {
+	game := w.Game
+	if game == nil || game.Board == nil {
+		game = &Game{}
+		if err := tx.Session(&gorm.Session{NewDB: true}).First(game, w.GameID).Error; err != nil {
+			return fmt.Errorf("error loading game %d: %w", w.GameID, err)
+		}
+	}
+	board, err := game.DumpBoard()
+	if err != nil {
+		return fmt.Errorf("error loading board: %w", err)
+	}
+	topology, err := game.DumpTopology()
+	if err != nil {
+		return fmt.Errorf("error loading topology: %w", err)
+	}
+	tiles, err := board.Resolve(topology, w.Path.Dump(), w.Text)
+	if err != nil {
+		return fmt.Errorf("error spelling word: %w", err)
+	}
+	var text strings.Builder
+	for _, t := range tiles {
+		text.WriteString(string(t))
+	}
+	w.Text = text.String()
+	return nil
+}
+
 type Player struct {
-	ID    int    `gorm:"primaryKey;not null"`
-	Name  string `gorm:"not null"`
-	Words []Word `gorm:"many2many:word_players"`
+	ID   int    `gorm:"primaryKey;not null"`
+	Name string `gorm:"not null;uniqueIndex:idx_player_login,where:password_hash IS NOT NULL"`
+	// PasswordHash is the bcrypt hash of the password of the player, or
+	// nil if they cannot log in.
+	PasswordHash []byte
+	Words        []Word `gorm:"many2many:word_players"`
+}
+
+// SetPassword sets the password by which the player logs in.
+func (p *Player) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	p.PasswordHash = hash
+	return nil
+}
+
+// dummyHash is the bcrypt hash of a password that is not known.
+var dummyHash = []byte("$2a$10$2Sp97P5qWSDiOI.avI.pIeU1S4VAzvX5DxBFSYgzi/R31/C7M2bFe")
+
+// CheckPassword returns whether password is the password of the player.
+// A player that has no password, such as one that was not found, takes
+// as long to check as one that does, so that the time taken does not
+// reveal which players exist.
+func (p *Player) CheckPassword(password string) bool {
+	if p.PasswordHash == nil {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword(p.PasswordHash, []byte(password)) == nil
 }
 
 type WordPlayer struct {
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 )
 
 var db *DB
@@ -62,10 +63,10 @@ func TestCreateGame_OK(t *testing.T) {
 	}
 
 	board := boggle.Board{
-		{'a', 'b', 'c', 'd'},
-		{'e', 'f', 'g', 'h'},
-		{'i', 'j', 'k', 'l'},
-		{'m', 'n', 'o', 'p'},
+		{"a", "b", "c", "d"},
+		{"e", "f", "g", "h"},
+		{"i", "j", "k", "l"},
+		{"m", "n", "o", "p"},
 	}
 	var game Game
 	game.LoadBoard(board)
@@ -86,9 +87,9 @@ func TestCreateGame_BoardTooLargeRows(t *testing.T) {
 	cardinality := 16
 	board := make(boggle.Board, cardinality+1)
 	for i := 0; i < cardinality+1; i++ {
-		board[i] = make([]rune, cardinality)
+		board[i] = make([]boggle.Tile, cardinality)
 		for j := range board[i] {
-			board[i][j] = '0' + rune(j)%10
+			board[i][j] = boggle.Tile('0' + rune(j)%10)
 		}
 	}
 	var game Game
@@ -106,12 +107,14 @@ func TestCreateGame_BoardTooLargeCols(t *testing.T) {
 		t.Skip("database not available")
 	}
 
+	// Rows are limited by their length once formatted, so use tiles of
+	// more than one letter to exceed it.
 	cardinality := 16
 	board := make(boggle.Board, cardinality)
 	for i := 0; i < cardinality; i++ {
-		board[i] = make([]rune, cardinality+1)
+		board[i] = make([]boggle.Tile, cardinality+1)
 		for j := range board[i] {
-			board[i][j] = '0' + rune(j)%10
+			board[i][j] = boggle.Tile([]rune{'0' + rune(j)%10, '0' + rune(j+1)%10})
 		}
 	}
 	var game Game
@@ -124,6 +127,95 @@ func TestCreateGame_BoardTooLargeCols(t *testing.T) {
 	})
 }
 
+func TestCreateGame_MultiLetterTiles(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+
+	board := boggle.Board{
+		{"qu", "i"},
+		{"t", "e"},
+	}
+	var game Game
+	game.LoadBoard(board)
+
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+		selectedGame := Game{ID: game.ID}
+		if result := tx.WithContext(ctx).Find(&selectedGame); result.Error != nil {
+			t.Fatalf("select game error: %v", result.Error)
+		}
+		selectedBoard, err := selectedGame.DumpBoard()
+		assert.NoError(t, err)
+		assert.Equal(t, board, selectedBoard)
+	})
+}
+
+func TestCreateGame_Shaped(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+
+	board := boggle.Board{
+		{boggle.Blocked, "a", boggle.Blocked},
+		{"b", "c", "d"},
+		{boggle.Blocked, "e"},
+	}
+	var game Game
+	game.LoadBoard(board)
+
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+		selectedGame := Game{ID: game.ID}
+		if result := tx.WithContext(ctx).Find(&selectedGame); result.Error != nil {
+			t.Fatalf("select game error: %v", result.Error)
+		}
+		selectedBoard, err := selectedGame.DumpBoard()
+		assert.NoError(t, err)
+		assert.Equal(t, board, selectedBoard)
+
+		// Blocked tiles cannot be spelled.
+		word := Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}, {1, 1}}}
+		assert.Error(t, tx.WithContext(ctx).Create(&word).Error)
+	})
+}
+
+func TestGame_Lifecycle(t *testing.T) {
+	start := time.Date(2022, 4, 1, 12, 0, 0, 0, time.UTC)
+	duration := 180
+	game := Game{Duration: &duration}
+	assert.Equal(t, GameCreated, game.Status(start))
+	assert.Equal(t, &StatusError{GameCreated}, game.Finish(start))
+
+	assert.NoError(t, game.Start(start))
+	assert.Equal(t, start, *game.StartedAt)
+	assert.Equal(t, start.Add(3*time.Minute), *game.EndsAt)
+	assert.Equal(t, GameRunning, game.Status(start))
+	assert.Equal(t, GameRunning, game.Status(start.Add(3*time.Minute-time.Second)))
+	assert.Equal(t, GameFinished, game.Status(start.Add(3*time.Minute)))
+	assert.Equal(t, &StatusError{GameRunning}, game.Start(start.Add(time.Second)))
+
+	// Finished early.
+	assert.NoError(t, game.Finish(start.Add(time.Minute)))
+	assert.Equal(t, GameFinished, game.Status(start.Add(time.Minute)))
+	assert.Equal(t, &StatusError{GameFinished}, game.Finish(start.Add(2*time.Minute)))
+	assert.Equal(t, &StatusError{GameFinished}, game.Start(start.Add(2*time.Minute)))
+
+	// Untimed rounds run until finished.
+	game = Game{}
+	assert.NoError(t, game.Start(start))
+	assert.Nil(t, game.EndsAt)
+	assert.Equal(t, GameRunning, game.Status(start.Add(24*time.Hour)))
+	assert.NoError(t, game.Finish(start.Add(24*time.Hour)))
+	assert.Equal(t, GameFinished, game.Status(start.Add(24*time.Hour)))
+}
+
 func TestCreateWord(t *testing.T) {
 	if db == nil {
 		t.Skip("database not available")
@@ -131,10 +223,10 @@ func TestCreateWord(t *testing.T) {
 	ctx := context.Background()
 	WithRollback(db, func(tx *DB) {
 		board := boggle.Board{
-			{'a', 'b', 'c', 'd'},
-			{'e', 'f', 'g', 'h'},
-			{'i', 'j', 'k', 'l'},
-			{'m', 'n', 'o', 'p'},
+			{"a", "b", "c", "d"},
+			{"e", "f", "g", "h"},
+			{"i", "j", "k", "l"},
+			{"m", "n", "o", "p"},
 		}
 		var game Game
 		game.LoadBoard(board)
@@ -156,6 +248,101 @@ func TestCreateWord(t *testing.T) {
 		}
 		assert.Equal(t, newWord.GameID, selectedWord.GameID)
 		assert.Equal(t, newWord.Path, selectedWord.Path)
+		assert.Equal(t, "afk", selectedWord.Text)
+	})
+}
+
+func TestCreateWord_Unique(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		var game Game
+		game.LoadBoard(boggle.Board{{"a", "b"}, {"c", "d"}})
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+		word := Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}}}
+		if result := tx.WithContext(ctx).Create(&word); result.Error != nil {
+			t.Fatalf("create word error: %v", result.Error)
+		}
+		word = Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}}}
+		assert.Error(t, tx.WithContext(ctx).Create(&word).Error)
+	})
+}
+
+func TestGame_DumpTopology(t *testing.T) {
+	var game Game
+	topology, err := game.DumpTopology()
+	assert.NoError(t, err)
+	assert.Equal(t, boggle.Square, topology)
+
+	name := "hex"
+	game.Topology = &name
+	topology, err = game.DumpTopology()
+	assert.NoError(t, err)
+	assert.Equal(t, boggle.Hex{}, topology)
+
+	name = "klein"
+	_, err = game.DumpTopology()
+	assert.Error(t, err)
+}
+
+func TestCreateWord_Topology(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		topology := "torus"
+		game := Game{Topology: &topology}
+		game.LoadBoard(boggle.Board{
+			{"t", "x", "c"},
+			{"x", "x", "a"},
+		})
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+
+		word := Word{
+			GameID: game.ID,
+			Path:   Path{{2, 0}, {2, 1}, {0, 0}},
+		}
+		if result := tx.WithContext(ctx).Create(&word); result.Error != nil {
+			t.Fatalf("create word error: %v", result.Error)
+		}
+		assert.Equal(t, "cat", word.Text)
+	})
+}
+
+func TestCreateWord_Wildcard(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		var game Game
+		game.LoadBoard(boggle.Board{{"c", boggle.Wildcard, "t"}})
+		if result := tx.WithContext(ctx).Create(&game); result.Error != nil {
+			t.Fatalf("create game error: %v", result.Error)
+		}
+
+		word := Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cot"}
+		if result := tx.WithContext(ctx).Create(&word); result.Error != nil {
+			t.Fatalf("create word error: %v", result.Error)
+		}
+		assert.Equal(t, "cot", word.Text)
+
+		// The text must be spelled by the path.
+		word = Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cab"}
+		assert.Error(t, tx.WithContext(ctx).Create(&word).Error)
+
+		// A path spells a word for each letter its wildcards stand for.
+		word = Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cut"}
+		assert.NoError(t, tx.WithContext(ctx).Create(&word).Error)
+		word = Word{GameID: game.ID, Path: Path{{0, 0}, {1, 0}, {2, 0}}, Text: "cut"}
+		assert.Error(t, tx.WithContext(ctx).Create(&word).Error)
 	})
 }
 
@@ -172,6 +359,40 @@ func TestCreatePlayer(t *testing.T) {
 	})
 }
 
+func TestPlayer_Password(t *testing.T) {
+	var player Player
+	assert.False(t, player.CheckPassword(""), "player without password can log in")
+	assert.False(t, player.CheckPassword("hunter22"), "player without password can log in")
+	assert.NoError(t, player.SetPassword("hunter22"))
+	assert.NotContains(t, string(player.PasswordHash), "hunter22")
+	assert.True(t, player.CheckPassword("hunter22"))
+	assert.False(t, player.CheckPassword("hunter2"))
+}
+
+func TestCreatePlayer_Login(t *testing.T) {
+	if db == nil {
+		t.Skip("database not available")
+	}
+	ctx := context.Background()
+	WithRollback(db, func(tx *DB) {
+		// Only players that can log in must have unique names.
+		for i := 0; i < 2; i++ {
+			player := Player{Name: "John Doe"}
+			if result := tx.WithContext(ctx).Create(&player); result.Error != nil {
+				t.Fatalf("create player error: %v", result.Error)
+			}
+		}
+		player := Player{Name: "John Doe"}
+		_ = player.SetPassword("hunter22")
+		if result := tx.WithContext(ctx).Create(&player); result.Error != nil {
+			t.Fatalf("create player error: %v", result.Error)
+		}
+		other := Player{Name: "John Doe"}
+		_ = other.SetPassword("hunter23")
+		assert.Error(t, tx.WithContext(ctx).Create(&other).Error)
+	})
+}
+
 func TestCreatePlayerWord(t *testing.T) {
 	if db == nil {
 		t.Skip("database not available")
@@ -179,10 +400,10 @@ func TestCreatePlayerWord(t *testing.T) {
 	ctx := context.Background()
 	WithRollback(db, func(tx *DB) {
 		board := boggle.Board{
-			{'a', 'b', 'c', 'd'},
-			{'e', 'f', 'g', 'h'},
-			{'i', 'j', 'k', 'l'},
-			{'m', 'n', 'o', 'p'},
+			{"a", "b", "c", "d"},
+			{"e", "f", "g", "h"},
+			{"i", "j", "k", "l"},
+			{"m", "n", "o", "p"},
 		}
 		var game Game
 		game.LoadBoard(board)
@@ -0,0 +1,17 @@
+package database
+
+import "time"
+
+// Session is a login of a player, which lasts until it expires or the
+// player logs out.
+type Session struct {
+	ID        int `gorm:"primaryKey;not null"`
+	PlayerID  int `gorm:"not null;index"`
+	Player    *Player
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+// IsExpired returns whether the session has expired at a time.
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,72 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker delivers each message published to a topic to the current
+// subscribers of the topic.
+//
+// Messages are small, such as the IDs of changed records, so that a
+// Broker may be backed by PostgreSQL LISTEN/NOTIFY.
+type Broker interface {
+	Publish(ctx context.Context, topic string, msg []byte) error
+	// Subscribe returns the messages published to a topic until the
+	// context is done, when the channel is closed.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+// DefaultBuffer is the number of messages a Memory broker holds for each
+// subscriber.
+const DefaultBuffer = 64
+
+// Memory is a Broker within a single process. Messages for a subscriber
+// that is not keeping up are dropped rather than delay the publisher.
+type Memory struct {
+	Buffer int
+
+	mu     sync.Mutex
+	topics map[string]map[chan []byte]struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{Buffer: DefaultBuffer}
+}
+
+func (m *Memory) Publish(_ context.Context, topic string, msg []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for ch := range m.topics[topic] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+	return nil
+}
+
+func (m *Memory) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, m.Buffer)
+	m.mu.Lock()
+	if m.topics == nil {
+		m.topics = make(map[string]map[chan []byte]struct{})
+	}
+	if m.topics[topic] == nil {
+		m.topics[topic] = make(map[chan []byte]struct{})
+	}
+	m.topics[topic][ch] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.topics[topic], ch)
+		if len(m.topics[topic]) == 0 {
+			delete(m.topics, topic)
+		}
+		close(ch)
+	}()
+	return ch, nil
+}
@@ -0,0 +1,63 @@
+package pubsub
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, ch <-chan []byte) ([]byte, bool) {
+	select {
+	case msg, ok := <-ch:
+		return msg, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil, false
+	}
+}
+
+func TestMemory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := NewMemory()
+
+	a, err := broker.Subscribe(ctx, "a")
+	assert.NoError(t, err)
+	b, err := broker.Subscribe(ctx, "a")
+	assert.NoError(t, err)
+	other, err := broker.Subscribe(ctx, "b")
+	assert.NoError(t, err)
+
+	assert.NoError(t, broker.Publish(ctx, "a", []byte("hello")))
+	for _, ch := range []<-chan []byte{a, b} {
+		msg, ok := receive(t, ch)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("hello"), msg)
+	}
+	assert.Len(t, other, 0, "message delivered to other topic")
+
+	// Subscriptions end with their context.
+	subCtx, subCancel := context.WithCancel(ctx)
+	sub, err := broker.Subscribe(subCtx, "a")
+	assert.NoError(t, err)
+	subCancel()
+	_, ok := receive(t, sub)
+	assert.False(t, ok, "channel not closed")
+}
+
+func TestMemory_Slow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := &Memory{Buffer: 1}
+	ch, err := broker.Subscribe(ctx, "a")
+	assert.NoError(t, err)
+
+	// Publishing does not wait for a subscriber that is not receiving.
+	for _, s := range []string{"first", "second"} {
+		assert.NoError(t, broker.Publish(ctx, "a", []byte(s)))
+	}
+	msg, _ := receive(t, ch)
+	assert.Equal(t, []byte("first"), msg)
+	assert.Len(t, ch, 0)
+}